@@ -9,9 +9,10 @@ type Publisher[T any] interface {
 
 // ClockStats contains observable metrics for a Clock.
 type ClockStats struct {
-	TickCount uint64
-	IsRunning bool
-	Interval  time.Duration
+	TickCount    uint64
+	SkippedTicks uint64 // ticks that fired but were never received
+	IsRunning    bool
+	Interval     time.Duration
 }
 
 // Clock provides timing signals for value updates.
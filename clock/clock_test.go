@@ -0,0 +1,418 @@
+package clock_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/neox5/simv/clock"
+)
+
+// ============================================================================
+// FUNCTIONAL TESTS
+// ============================================================================
+
+// TestScaledClock_InvalidPeriod verifies a scaled period that would round
+// down to zero panics at construction rather than in the run goroutine.
+func TestScaledClock_InvalidPeriod(t *testing.T) {
+	for name, tc := range map[string]struct {
+		interval time.Duration
+		speed    float64
+	}{
+		"zero speed":     {time.Second, 0},
+		"negative speed": {time.Second, -2},
+		"below 1ns":      {time.Nanosecond, 10},
+		"zero interval":  {0, 1},
+		"huge speed":     {time.Millisecond, 1e12},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			clock.NewScaledClock(tc.interval, tc.speed)
+		})
+	}
+}
+
+// TestPeriodicClock_SkippedTicks verifies fires that no subscriber
+// receives are counted as skipped, apart from the one still pending.
+func TestPeriodicClock_SkippedTicks(t *testing.T) {
+	c := clock.NewPeriodicClock(2 * time.Millisecond)
+	c.Subscribe() // never read
+	c.Start()
+	time.Sleep(30 * time.Millisecond)
+	c.Stop()
+
+	stats := c.Stats()
+	if stats.SkippedTicks < 3 || stats.TickCount-stats.SkippedTicks != 1 {
+		t.Errorf("got ticks=%d skipped=%d, want all but the pending tick skipped",
+			stats.TickCount, stats.SkippedTicks)
+	}
+}
+
+// TestBufferedPeriodicClock_BuffersTicks verifies a stalled subscriber
+// finds the buffered ticks once it reads, and only the overflow is skipped.
+func TestBufferedPeriodicClock_BuffersTicks(t *testing.T) {
+	const bufSize = 4
+	c := clock.NewBufferedPeriodicClock(2*time.Millisecond, bufSize)
+	ticks := c.Subscribe()
+	c.Start()
+	time.Sleep(30 * time.Millisecond)
+	c.Stop()
+
+	received := 0
+	for range ticks {
+		received++
+	}
+	if received != bufSize {
+		t.Errorf("received %d buffered ticks, want %d", received, bufSize)
+	}
+	stats := c.Stats()
+	if stats.TickCount-stats.SkippedTicks != bufSize+1 {
+		t.Errorf("got ticks=%d skipped=%d, want all but %d buffered and 1 pending skipped",
+			stats.TickCount, stats.SkippedTicks, bufSize)
+	}
+}
+
+// TestPeriodicClock_Heartbeat verifies heartbeats arrive every n fires
+// while no subscriber receives ticks, and the channel closes on Stop.
+func TestPeriodicClock_Heartbeat(t *testing.T) {
+	const period = 2 * time.Millisecond
+	c := clock.NewPeriodicClock(period)
+	c.SetHeartbeatEvery(3)
+	c.Subscribe() // never read
+	c.Start()
+
+	var beats []time.Time
+	for range 3 {
+		select {
+		case at := <-c.Heartbeat():
+			beats = append(beats, at)
+		case <-time.After(time.Second):
+			t.Fatal("no heartbeat")
+		}
+	}
+	c.Stop()
+
+	for i := 1; i < len(beats); i++ {
+		if gap := beats[i].Sub(beats[i-1]); gap < 3*period-time.Millisecond {
+			t.Errorf("heartbeat gap %v, want at least 3 periods", gap)
+		}
+	}
+	if _, ok := <-c.Heartbeat(); ok {
+		// a last heartbeat may be buffered; the channel must close after it
+		if _, ok := <-c.Heartbeat(); ok {
+			t.Error("heartbeat channel open after Stop")
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("SetHeartbeatEvery(0): expected panic")
+		}
+	}()
+	clock.NewPeriodicClock(period).SetHeartbeatEvery(0)
+}
+
+// fireTimes records tick fire times of c through OnTick.
+func fireTimes(c *clock.PeriodicClock) func() []time.Time {
+	var (
+		mu    sync.Mutex
+		times []time.Time
+	)
+	c.OnTick(func(now time.Time) {
+		mu.Lock()
+		defer mu.Unlock()
+		times = append(times, now)
+	})
+	return func() []time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]time.Time(nil), times...)
+	}
+}
+
+// TestPeriodicClock_OnTick verifies callbacks run in registration order on
+// every fire, including skipped ticks, and not after Stop returns.
+func TestPeriodicClock_OnTick(t *testing.T) {
+	c := clock.NewPeriodicClock(2 * time.Millisecond)
+	var (
+		mu    sync.Mutex
+		calls []string
+	)
+	for _, name := range []string{"a", "b"} {
+		c.OnTick(func(time.Time) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls = append(calls, name)
+		})
+	}
+	times := fireTimes(c)
+	c.Subscribe() // never read: ticks are skipped
+	c.Start()
+
+	deadline := time.Now().Add(time.Second)
+	for len(times()) < 3 {
+		if time.Now().After(deadline) {
+			t.Fatal("callbacks not called")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	c.Stop()
+
+	mu.Lock()
+	got := append([]string(nil), calls...)
+	mu.Unlock()
+	if len(got)%2 != 0 {
+		t.Fatalf("got %v, want both callbacks per fire", got)
+	}
+	for i := 0; i < len(got); i += 2 {
+		if got[i] != "a" || got[i+1] != "b" {
+			t.Fatalf("got %v, want a before b on every fire", got)
+		}
+	}
+	if n := len(times()); n != len(got)/2 {
+		t.Errorf("fireTimes saw %d fires, want %d", n, len(got)/2)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	if len(calls) != len(got) {
+		t.Errorf("callbacks ran after Stop: %d calls, want %d", len(calls), len(got))
+	}
+}
+
+// TestPeriodicClock_WaitTick verifies WaitTick times out without fires,
+// wakes every waiter on a fire, and leaves ticks to subscribers.
+func TestPeriodicClock_WaitTick(t *testing.T) {
+	c := clock.NewPeriodicClock(5 * time.Millisecond)
+	ticks := c.Subscribe()
+	if c.WaitTick(10 * time.Millisecond) {
+		t.Error("before Start: got a fire, want timeout")
+	}
+
+	const waiters = 3
+	results := make(chan bool, waiters)
+	for range waiters {
+		go func() { results <- c.WaitTick(time.Second) }()
+	}
+	c.Start()
+	defer c.Stop()
+
+	for range waiters {
+		if !<-results {
+			t.Error("waiter timed out while the clock was running")
+		}
+	}
+	select {
+	case <-ticks:
+	case <-time.After(time.Second):
+		t.Error("subscriber got no tick")
+	}
+}
+
+// TestPeriodicClockWithPhase_Offset verifies the first tick waits for the
+// phase plus one interval, and Stop cancels a pending phase without a tick.
+func TestPeriodicClockWithPhase_Offset(t *testing.T) {
+	const interval, phase = 5 * time.Millisecond, 40 * time.Millisecond
+	c := clock.NewPeriodicClockWithPhase(interval, phase)
+	ticks := c.Subscribe()
+	begin := time.Now()
+	c.Start()
+	<-ticks
+	c.Stop()
+	if elapsed := time.Since(begin); elapsed < phase+interval {
+		t.Errorf("first tick after %v, want at least %v", elapsed, phase+interval)
+	}
+
+	waiting := clock.NewPeriodicClockWithPhase(interval, time.Hour)
+	waiting.Start()
+	time.Sleep(5 * time.Millisecond)
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		waiting.Stop()
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Stop blocked on the phase wait")
+	}
+	if stats := waiting.Stats(); stats.TickCount != 0 || stats.IsRunning {
+		t.Errorf("after Stop in phase: got %+v, want no ticks and stopped", stats)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("negative phase: expected panic")
+		}
+	}()
+	clock.NewPeriodicClockWithPhase(interval, -time.Millisecond)
+}
+
+// TestGroup_AlignedTicks verifies children of different rates tick on the
+// shared epoch, so their common multiples coincide.
+func TestGroup_AlignedTicks(t *testing.T) {
+	const tolerance = 8 * time.Millisecond
+	g := clock.NewGroup()
+	fast := g.Periodic(20 * time.Millisecond)
+	slow := g.Periodic(40 * time.Millisecond)
+	fastTimes, slowTimes := fireTimes(fast), fireTimes(slow)
+
+	before := time.Now()
+	g.StartAll()
+	time.Sleep(100 * time.Millisecond)
+	g.StopAll()
+
+	f, s := fastTimes(), slowTimes()
+	if len(f) < 2 || len(s) < 1 {
+		t.Fatalf("got %d fast and %d slow ticks, want at least 2 and 1", len(f), len(s))
+	}
+	if d := f[0].Sub(before); d < 20*time.Millisecond || d > 20*time.Millisecond+tolerance {
+		t.Errorf("first fast tick %v after start, want 20ms", d)
+	}
+	// The second fast tick and the first slow tick are both due at 40ms
+	if d := s[0].Sub(f[1]).Abs(); d > tolerance {
+		t.Errorf("second fast and first slow tick %v apart, want aligned", d)
+	}
+}
+
+// TestGroup_SimTimeAndStop verifies SimTime runs from StartAll and freezes
+// at StopAll, which closes every child and is safe to repeat.
+func TestGroup_SimTimeAndStop(t *testing.T) {
+	g := clock.NewGroup()
+	a := g.Periodic(time.Millisecond)
+	b := g.Periodic(3 * time.Millisecond)
+
+	if got := g.SimTime(); got != 0 {
+		t.Errorf("before start: got %v, want 0", got)
+	}
+
+	g.StartAll()
+	time.Sleep(10 * time.Millisecond)
+	if got := g.SimTime(); got < 10*time.Millisecond {
+		t.Errorf("running: got %v, want at least 10ms", got)
+	}
+
+	g.StopAll()
+	g.StopAll()
+	frozen := g.SimTime()
+	time.Sleep(5 * time.Millisecond)
+	if got := g.SimTime(); got != frozen {
+		t.Errorf("after stop: got %v then %v, want frozen", frozen, got)
+	}
+	for name, c := range map[string]*clock.PeriodicClock{"a": a, "b": b} {
+		for range c.Subscribe() {
+		}
+		if c.Stats().IsRunning {
+			t.Errorf("clock %s still running after StopAll", name)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Periodic after StartAll: expected panic")
+		}
+	}()
+	g.Periodic(time.Millisecond)
+}
+
+// TestScheduleClock_Offsets verifies ticks follow the offsets from Start,
+// none is skipped for a late subscriber, and the channel closes at the end.
+func TestScheduleClock_Offsets(t *testing.T) {
+	offsets := []time.Duration{5 * time.Millisecond, 5 * time.Millisecond, 30 * time.Millisecond}
+	c := clock.NewScheduleClock(offsets)
+	defer c.Stop()
+
+	start := time.Now()
+	c.Start()
+	ticks := c.Subscribe()
+
+	time.Sleep(15 * time.Millisecond) // late for the first two ticks
+	var got []time.Duration
+	for range ticks {
+		got = append(got, time.Since(start))
+	}
+	if len(got) != len(offsets) {
+		t.Fatalf("got %d ticks, want %d", len(got), len(offsets))
+	}
+	if got[2] < offsets[2] {
+		t.Errorf("last tick at %v, want no earlier than %v", got[2], offsets[2])
+	}
+	if stats := c.Stats(); stats.TickCount != 3 || stats.IsRunning {
+		t.Errorf("got ticks=%d running=%v, want 3 and false", stats.TickCount, stats.IsRunning)
+	}
+}
+
+// TestScheduleClock_InvalidOffsets verifies unordered or negative offsets
+// panic at construction.
+func TestScheduleClock_InvalidOffsets(t *testing.T) {
+	for name, offsets := range map[string][]time.Duration{
+		"negative":  {-time.Millisecond},
+		"unordered": {2 * time.Millisecond, time.Millisecond},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			clock.NewScheduleClock(offsets)
+		})
+	}
+}
+
+// TestLockstepClock_WaitsForAck verifies the next tick is held back until
+// Ack, and Stop closes the channel even while waiting.
+func TestLockstepClock_WaitsForAck(t *testing.T) {
+	c := clock.NewLockstepClock()
+	c.Start()
+	ticks := c.Subscribe()
+
+	<-ticks
+	select {
+	case <-ticks:
+		t.Fatal("tick delivered without Ack")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	c.Ack()
+	select {
+	case <-ticks:
+	case <-time.After(time.Second):
+		t.Fatal("no tick after Ack")
+	}
+
+	c.Stop()
+	c.Stop()
+	if _, ok := <-ticks; ok {
+		t.Error("tick channel open after Stop")
+	}
+	if got := c.AckTimeouts(); got != 0 {
+		t.Errorf("got %d ack timeouts, want 0", got)
+	}
+}
+
+// TestLockstepClock_AckTimeout verifies ticks continue without Ack once
+// the ack timeout expires, and each is counted.
+func TestLockstepClock_AckTimeout(t *testing.T) {
+	c := clock.NewLockstepClock()
+	c.SetAckTimeout(2 * time.Millisecond)
+	c.Start()
+	defer c.Stop()
+
+	ticks := c.Subscribe()
+	for range 3 {
+		select {
+		case <-ticks:
+		case <-time.After(time.Second):
+			t.Fatal("tick not released by the ack timeout")
+		}
+	}
+	if got := c.AckTimeouts(); got < 2 {
+		t.Errorf("got %d ack timeouts, want at least 2", got)
+	}
+}
@@ -0,0 +1,94 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Group coordinates periodic clocks of different rates that share one
+// simulation time, so timestamps of pipelines sampled at different rates
+// are comparable.
+type Group struct {
+	mu       sync.Mutex
+	clocks   []*PeriodicClock
+	start    time.Time // set by StartAll
+	stopped  time.Time // set by StopAll
+	started  bool
+	stopOnce sync.Once
+}
+
+// NewGroup creates an empty clock group.
+func NewGroup() *Group {
+	return &Group{}
+}
+
+// Periodic creates a periodic clock owned by g that ticks at interval.
+// It is started and stopped with the group and anchored to the group
+// start: its ticks fire at multiples of interval after the instant
+// StartAll records, however long starting the other clocks takes, so
+// ticks of all children line up on the shared epoch. Do not call its
+// Start or Stop directly.
+// Panics if called after StartAll.
+func (g *Group) Periodic(interval time.Duration) *PeriodicClock {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.started {
+		panic("clock group: cannot add clock after StartAll()")
+	}
+	c := NewPeriodicClock(interval)
+	g.clocks = append(g.clocks, c)
+	return c
+}
+
+// StartAll records the group start, the origin of SimTime and of every
+// clock's ticks, and starts every clock.
+// Panics if called more than once.
+func (g *Group) StartAll() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.started {
+		panic("clock group already started")
+	}
+	g.started = true
+	g.start = time.Now()
+	for _, c := range g.clocks {
+		c.anchor = g.start
+		c.Start()
+	}
+}
+
+// StopAll stops every clock, closing their tick channels, and freezes
+// SimTime. Has no effect if the group was not started.
+// Safe to call multiple times.
+func (g *Group) StopAll() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.started {
+		return
+	}
+	g.stopOnce.Do(func() {
+		g.stopped = time.Now()
+		for _, c := range g.clocks {
+			c.Stop()
+		}
+	})
+}
+
+// SimTime returns the simulation time elapsed since StartAll: zero before
+// the group is started, and the total run time once it is stopped.
+func (g *Group) SimTime() time.Duration {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	switch {
+	case !g.started:
+		return 0
+	case !g.stopped.IsZero():
+		return g.stopped.Sub(g.start)
+	default:
+		return time.Since(g.start)
+	}
+}
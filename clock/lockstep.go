@@ -0,0 +1,131 @@
+package clock
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// LockstepClock ticks only after the previous tick has been acknowledged,
+// for closed-loop simulations where each step must be fully processed
+// before the next begins.
+type LockstepClock struct {
+	tickChan   chan struct{}
+	ack        chan struct{}
+	stop       chan struct{}
+	stopOnce   sync.Once
+	wg         sync.WaitGroup
+	tickCount  atomic.Uint64
+	timeouts   atomic.Uint64
+	running    atomic.Bool
+	ackTimeout time.Duration
+}
+
+// NewLockstepClock creates a clock that delivers one tick, then waits for
+// Ack() before delivering the next. Ticks are never skipped, so every
+// tick is processed exactly once, however long processing takes.
+//
+// Deadlock avoidance: if nothing ever acks, the clock waits forever by
+// default, but Stop() always returns. Use SetAckTimeout to fire anyway
+// after a maximum wait instead.
+func NewLockstepClock() *LockstepClock {
+	return &LockstepClock{
+		tickChan: make(chan struct{}),
+		ack:      make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+	}
+}
+
+// SetAckTimeout makes the clock deliver the next tick if no Ack() arrives
+// within d of the previous tick being received. Each such tick is counted
+// in AckTimeouts(). A non-positive d waits forever (the default).
+// Must be called before Start().
+func (c *LockstepClock) SetAckTimeout(d time.Duration) {
+	c.ackTimeout = d
+}
+
+// Ack signals that the current tick has been processed, releasing the
+// next one. Typically called from the consumer once its update completes,
+// e.g. from a value's UpdateHook AfterUpdate. Never blocks; acks are not
+// counted, so several calls between ticks release only one tick, and an
+// Ack before the pending tick is received releases the tick after it.
+func (c *LockstepClock) Ack() {
+	select {
+	case c.ack <- struct{}{}:
+	default:
+	}
+}
+
+// AckTimeouts returns the number of ticks released by the ack timeout
+// rather than by Ack().
+func (c *LockstepClock) AckTimeouts() uint64 {
+	return c.timeouts.Load()
+}
+
+// Start delivers the first tick, and each further one after an Ack().
+func (c *LockstepClock) Start() {
+	c.running.Store(true)
+	c.wg.Go(c.run)
+}
+
+func (c *LockstepClock) run() {
+	for {
+		select {
+		case c.tickChan <- struct{}{}:
+			c.tickCount.Add(1)
+		case <-c.stop:
+			return
+		}
+
+		if !c.waitAck() {
+			return
+		}
+	}
+}
+
+// waitAck waits for Ack() or the ack timeout.
+// Returns false if the clock was stopped.
+func (c *LockstepClock) waitAck() bool {
+	var timeout <-chan time.Time
+	if c.ackTimeout > 0 {
+		timer := time.NewTimer(c.ackTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case <-c.ack:
+		return true
+	case <-timeout:
+		c.timeouts.Add(1)
+		return true
+	case <-c.stop:
+		return false
+	}
+}
+
+// Stop stops the clock and closes the tick channel.
+// Safe to call multiple times.
+func (c *LockstepClock) Stop() {
+	c.stopOnce.Do(func() {
+		c.running.Store(false)
+		close(c.stop)
+		c.wg.Wait()
+		close(c.tickChan)
+	})
+}
+
+// Subscribe returns the channel that receives tick events.
+func (c *LockstepClock) Subscribe() <-chan struct{} {
+	return c.tickChan
+}
+
+// Stats returns current clock metrics.
+// Interval is always zero, since ticks follow acknowledgements.
+// SkippedTicks is always zero, since ticks are never skipped.
+func (c *LockstepClock) Stats() ClockStats {
+	return ClockStats{
+		TickCount: c.tickCount.Load(),
+		IsRunning: c.running.Load(),
+	}
+}
@@ -1,6 +1,7 @@
 package clock
 
 import (
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -8,39 +9,166 @@ import (
 
 // PeriodicClock generates ticks at fixed intervals.
 type PeriodicClock struct {
-	interval  time.Duration
+	interval  time.Duration // reported (simulated) interval
+	period    time.Duration // real time between ticks
+	phase     time.Duration // real delay before the ticker starts
+	anchor    time.Time     // shared epoch of a Group, zero otherwise
 	ticker    *time.Ticker
 	tickChan  chan struct{}
 	stop      chan struct{}
 	wg        sync.WaitGroup
 	tickCount atomic.Uint64
+	skipped   atomic.Uint64
 	running   atomic.Bool
+
+	// Liveness (independent of tick delivery)
+	heartbeat      chan time.Time
+	heartbeatEvery uint64
+
+	// Tick callbacks (run on their own goroutine)
+	onTick    []func(time.Time)
+	tickTimes chan time.Time
+
+	// Tick signal for WaitTick, closed and replaced on every fire
+	signalMu   sync.Mutex
+	tickSignal chan struct{}
 }
 
 // NewPeriodicClock creates a new clock that ticks at the specified interval.
 func NewPeriodicClock(interval time.Duration) *PeriodicClock {
+	return newPeriodicClock(interval, interval, 0)
+}
+
+// NewPeriodicClockWithPhase creates a clock that ticks at the specified
+// interval, offset by phase: the ticker starts phase after Start(), so ticks
+// fire at phase+interval, phase+2*interval, and so on.
+// Clocks sharing an interval but using different phases are staggered,
+// which avoids synchronized bursts across pipelines.
+// Stop() during the phase delay cancels it without firing a tick.
+// Panics if phase is negative.
+func NewPeriodicClockWithPhase(interval, phase time.Duration) *PeriodicClock {
+	if phase < 0 {
+		panic("clock: phase must not be negative")
+	}
+	c := newPeriodicClock(interval, interval, 0)
+	c.phase = phase
+	return c
+}
+
+// NewBufferedPeriodicClock creates a periodic clock whose tick channel
+// buffers up to bufSize ticks.
+// Short subscriber stalls no longer skip ticks; instead, the buffered ticks
+// are delivered in a burst once the subscriber catches up. Ticks are only
+// skipped (and counted in SkippedTicks) once the buffer is full.
+func NewBufferedPeriodicClock(interval time.Duration, bufSize int) *PeriodicClock {
+	return newPeriodicClock(interval, interval, bufSize)
+}
+
+// NewScaledClock creates a clock for accelerated simulations.
+// Ticks fire every interval/speed in real time, while Stats().Interval
+// reports the simulated interval, so time-based consumers can compute in
+// simulated time. A speed of 60 runs one simulated hour per real minute.
+// Panics if speed is not positive, or if interval/speed is shorter than
+// one nanosecond, the resolution of a ticker period.
+func NewScaledClock(interval time.Duration, speed float64) *PeriodicClock {
+	if !(speed > 0) {
+		panic("clock: speed must be positive")
+	}
+	period := time.Duration(float64(interval) / speed)
+	if period <= 0 {
+		panic(fmt.Sprintf("clock: scaled period of interval %v at speed %g is below 1ns", interval, speed))
+	}
+	return newPeriodicClock(interval, period, 0)
+}
+
+// newPeriodicClock creates a clock reporting interval that ticks every
+// period, with a tick channel buffer of bufSize.
+func newPeriodicClock(interval, period time.Duration, bufSize int) *PeriodicClock {
 	return &PeriodicClock{
-		interval: interval,
-		tickChan: make(chan struct{}),
-		stop:     make(chan struct{}),
+		interval:       interval,
+		period:         period,
+		tickChan:       make(chan struct{}, bufSize),
+		stop:           make(chan struct{}),
+		heartbeat:      make(chan time.Time, 1),
+		heartbeatEvery: 1,
+		tickTimes:      make(chan time.Time, 1),
+		tickSignal:     make(chan struct{}),
+	}
+}
+
+// SetHeartbeatEvery sets how many ticks pass between heartbeats.
+// Must be called before Start(). Panics if n is not positive.
+func (c *PeriodicClock) SetHeartbeatEvery(n int) {
+	if n <= 0 {
+		panic("clock: heartbeat interval must be positive")
 	}
+	c.heartbeatEvery = uint64(n)
+}
+
+// Heartbeat returns a channel that receives the current time every
+// heartbeat interval (every tick by default, see SetHeartbeatEvery).
+// Heartbeats reflect the clock firing, whether or not subscribers receive
+// the tick, so a watchdog can detect a stalled clock without consuming
+// data ticks. Only the latest heartbeat is kept if the channel is not read.
+// The channel is closed by Stop().
+func (c *PeriodicClock) Heartbeat() <-chan time.Time {
+	return c.heartbeat
+}
+
+// OnTick registers a callback invoked with the fire time of each tick.
+// Must be called before Start().
+// Callbacks run in registration order on a single dedicated goroutine, so
+// they never delay tick delivery to subscribers. If callbacks fall behind,
+// pending ticks are coalesced and the next call receives the latest fire
+// time. Callbacks see every ticker fire, including skipped ticks.
+// Stop() waits for a running callback to return.
+func (c *PeriodicClock) OnTick(fn func(time.Time)) {
+	c.onTick = append(c.onTick, fn)
 }
 
 // Start begins generating ticks.
 func (c *PeriodicClock) Start() {
-	c.ticker = time.NewTicker(c.interval)
 	c.running.Store(true)
+	if len(c.onTick) > 0 {
+		c.wg.Go(c.runCallbacks)
+	}
 	c.wg.Go(c.run)
 }
 
+// runCallbacks invokes tick callbacks until Stop().
+func (c *PeriodicClock) runCallbacks() {
+	for {
+		select {
+		case now := <-c.tickTimes:
+			for _, fn := range c.onTick {
+				fn(now)
+			}
+		case <-c.stop:
+			return
+		}
+	}
+}
+
 func (c *PeriodicClock) run() {
+	if !c.waitPhase() {
+		return
+	}
+	c.ticker = time.NewTicker(c.period)
+	defer c.ticker.Stop()
+
+	// An anchored clock's first tick is due when the wait ends
+	if !c.anchor.IsZero() {
+		c.fire(time.Now())
+		if !c.deliver() {
+			return
+		}
+	}
+
 	for {
 		select {
-		case <-c.ticker.C:
-			c.tickCount.Add(1)
-			select {
-			case c.tickChan <- struct{}{}:
-			case <-c.stop:
+		case now := <-c.ticker.C:
+			c.fire(now)
+			if !c.deliver() {
 				return
 			}
 		case <-c.stop:
@@ -49,15 +177,107 @@ func (c *PeriodicClock) run() {
 	}
 }
 
+// waitPhase waits out the phase delay, or for an anchored clock until its
+// first tick is due at anchor+phase+period. Returns false if the clock was
+// stopped first.
+func (c *PeriodicClock) waitPhase() bool {
+	delay := c.phase
+	if !c.anchor.IsZero() {
+		delay = time.Until(c.anchor.Add(c.phase + c.period))
+	}
+	if delay <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-c.stop:
+		return false
+	}
+}
+
+// fire records a ticker fire at now, notifies tick callbacks and emits
+// heartbeats.
+func (c *PeriodicClock) fire(now time.Time) {
+	n := c.tickCount.Add(1)
+	c.signalTick()
+	if len(c.onTick) > 0 {
+		sendLatest(c.tickTimes, now)
+	}
+	if n%c.heartbeatEvery != 0 {
+		return
+	}
+	sendLatest(c.heartbeat, now)
+}
+
+// signalTick wakes all WaitTick callers.
+func (c *PeriodicClock) signalTick() {
+	c.signalMu.Lock()
+	defer c.signalMu.Unlock()
+
+	close(c.tickSignal)
+	c.tickSignal = make(chan struct{})
+}
+
+// WaitTick blocks until the clock fires next or timeout elapses, and
+// reports whether it fired. It observes ticker fires without receiving from
+// the tick channel, so it never takes a tick from subscribers, and any
+// number of callers may wait at once. Skipped ticks count as fires.
+func (c *PeriodicClock) WaitTick(timeout time.Duration) bool {
+	c.signalMu.Lock()
+	signal := c.tickSignal
+	c.signalMu.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-signal:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// sendLatest sends now on ch, replacing an unread value so the channel
+// always holds the latest. Only the run goroutine sends on ch.
+func sendLatest(ch chan time.Time, now time.Time) {
+	select {
+	case <-ch:
+	default:
+	}
+	ch <- now
+}
+
+// deliver hands the current tick to a subscriber.
+// If the ticker fires again before any subscriber receives, the pending
+// tick is counted as skipped and replaced by the new one.
+// Returns false if the clock was stopped.
+func (c *PeriodicClock) deliver() bool {
+	for {
+		select {
+		case c.tickChan <- struct{}{}:
+			return true
+		case now := <-c.ticker.C:
+			c.fire(now)
+			c.skipped.Add(1)
+		case <-c.stop:
+			return false
+		}
+	}
+}
+
 // Stop stops the clock and closes the tick channel.
 func (c *PeriodicClock) Stop() {
-	if c.ticker != nil {
-		c.ticker.Stop()
-	}
 	c.running.Store(false)
 	close(c.stop)
 	c.wg.Wait()
 	close(c.tickChan)
+	close(c.heartbeat)
 }
 
 // Subscribe returns the channel that receives tick events.
@@ -68,8 +288,9 @@ func (c *PeriodicClock) Subscribe() <-chan struct{} {
 // Stats returns current clock metrics.
 func (c *PeriodicClock) Stats() ClockStats {
 	return ClockStats{
-		TickCount: c.tickCount.Load(),
-		IsRunning: c.running.Load(),
-		Interval:  c.interval,
+		TickCount:    c.tickCount.Load(),
+		SkippedTicks: c.skipped.Load(),
+		IsRunning:    c.running.Load(),
+		Interval:     c.interval,
 	}
 }
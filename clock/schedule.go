@@ -0,0 +1,112 @@
+package clock
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ScheduleClock replays a recorded tick schedule.
+type ScheduleClock struct {
+	offsets   []time.Duration
+	tickChan  chan struct{}
+	stop      chan struct{}
+	stopOnce  sync.Once
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+	tickCount atomic.Uint64
+	running   atomic.Bool
+}
+
+// NewScheduleClock creates a clock that ticks at the given offsets,
+// each measured from Start() (not from the previous tick).
+// After the last tick it stops and closes the tick channel, so pipelines
+// driven by it terminate.
+// Ticks are never skipped: a tick the subscriber is not ready for is
+// delivered late, and later ticks are delayed behind it.
+// Panics if offsets are negative or not in non-decreasing order.
+func NewScheduleClock(offsets []time.Duration) *ScheduleClock {
+	for i, off := range offsets {
+		if off < 0 {
+			panic("clock: schedule offsets must not be negative")
+		}
+		if i > 0 && off < offsets[i-1] {
+			panic("clock: schedule offsets must be in non-decreasing order")
+		}
+	}
+
+	return &ScheduleClock{
+		offsets:  append([]time.Duration(nil), offsets...),
+		tickChan: make(chan struct{}),
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start begins replaying the schedule.
+func (c *ScheduleClock) Start() {
+	c.running.Store(true)
+	start := time.Now()
+	c.wg.Go(func() { c.run(start) })
+}
+
+func (c *ScheduleClock) run(start time.Time) {
+	defer c.closeTicks()
+	defer c.running.Store(false)
+
+	for _, off := range c.offsets {
+		if !c.sleepUntil(start.Add(off)) {
+			return
+		}
+		c.tickCount.Add(1)
+
+		// Deliver late rather than skip
+		select {
+		case c.tickChan <- struct{}{}:
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// sleepUntil waits until t. Returns false if the clock was stopped.
+func (c *ScheduleClock) sleepUntil(t time.Time) bool {
+	timer := time.NewTimer(time.Until(t))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-c.stop:
+		return false
+	}
+}
+
+// closeTicks closes the tick channel exactly once.
+func (c *ScheduleClock) closeTicks() {
+	c.closeOnce.Do(func() { close(c.tickChan) })
+}
+
+// Stop stops the clock and closes the tick channel.
+// Safe to call after the schedule has completed.
+func (c *ScheduleClock) Stop() {
+	c.stopOnce.Do(func() {
+		c.running.Store(false)
+		close(c.stop)
+		c.wg.Wait()
+		c.closeTicks()
+	})
+}
+
+// Subscribe returns the channel that receives tick events.
+func (c *ScheduleClock) Subscribe() <-chan struct{} {
+	return c.tickChan
+}
+
+// Stats returns current clock metrics.
+// Interval is always zero, since scheduled ticks are irregular.
+func (c *ScheduleClock) Stats() ClockStats {
+	return ClockStats{
+		TickCount: c.tickCount.Load(),
+		IsRunning: c.running.Load(),
+	}
+}
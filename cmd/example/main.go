@@ -68,14 +68,14 @@ func main() {
 	)
 
 	accumulatedStats := accumulated.Stats()
-	fmt.Printf("Accumulated: updates=%d current=%d transforms=%d\n",
+	fmt.Printf("Accumulated: updates=%d current=%v transforms=%d\n",
 		accumulatedStats.UpdateCount,
 		accumulatedStats.CurrentValue,
 		accumulatedStats.TransformCount,
 	)
 
 	resetStats := resetOnRead.Stats()
-	fmt.Printf("ResetOnRead: updates=%d current=%d transforms=%d\n",
+	fmt.Printf("ResetOnRead: updates=%d current=%v transforms=%d\n",
 		resetStats.UpdateCount,
 		resetStats.CurrentValue,
 		resetStats.TransformCount,
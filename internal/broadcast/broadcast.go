@@ -0,0 +1,186 @@
+// Package broadcast implements the subscriber bookkeeping shared by simv
+// sources and values.
+package broadcast
+
+import "sync"
+
+// Broadcaster fans out published values to a set of subscriber channels.
+// Embedding it gives a publisher its Subscribe, Unsubscribe and
+// SubscribeWithCancel methods; the publishing side is driven with the
+// package functions (Publish, Close, ...), which are not promoted, so they
+// stay internal to the embedding type. The zero value is ready to use.
+//
+// Semantics shared by every publisher built on it:
+//   - Subscriber channels are unbuffered. Each published value is sent to
+//     every subscriber in subscription order, and a send blocks until it
+//     is received, the channel is unsubscribed, or the publisher gives up
+//     (see Publish), so subscribers must keep up.
+//   - Unsubscribe removes a channel and closes it. The publisher stops
+//     sending to it, including a send already in progress, so a consumer
+//     that stops reading never blocks the publisher.
+//   - Close closes every subscriber channel; a Subscribe after Close
+//     returns an already closed channel.
+type Broadcaster[T any] struct {
+	mu     sync.Mutex
+	subs   []*subscription[T]
+	closed bool
+
+	startOnce sync.Once
+	start     func() // run before the first subscriber is registered
+}
+
+// subscription is one subscriber channel. done is closed on unsubscribe,
+// abandoning any pending send; mu serializes sends with closing ch.
+type subscription[T any] struct {
+	ch     chan T
+	done   chan struct{}
+	mu     sync.Mutex
+	closed bool // protected by mu
+
+	// Sequenced publishes up to skipThrough were replayed on subscribe
+	skipThrough uint64
+}
+
+// send delivers value unless the subscription is cancelled or stop is
+// closed, or seq was already replayed. Returns false if stop was closed
+// first.
+func (sub *subscription[T]) send(value T, seq uint64, stop <-chan struct{}) bool {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	if sub.closed || seq != 0 && seq <= sub.skipThrough {
+		return true
+	}
+	select {
+	case sub.ch <- value:
+	case <-sub.done:
+	case <-stop:
+		return false
+	}
+	return true
+}
+
+// close closes ch once, waiting for an in-flight send.
+func (sub *subscription[T]) close() {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	if !sub.closed {
+		sub.closed = true
+		close(sub.ch)
+	}
+}
+
+// Subscribe returns a new channel that receives every value published from
+// now on.
+func (b *Broadcaster[T]) Subscribe() <-chan T {
+	b.startOnce.Do(func() {
+		if b.start != nil {
+			b.start()
+		}
+	})
+	return b.register(make(chan T), 0)
+}
+
+// Unsubscribe removes a channel returned by Subscribe and closes it.
+// Unknown channels are ignored.
+func (b *Broadcaster[T]) Unsubscribe(ch <-chan T) {
+	b.mu.Lock()
+	var sub *subscription[T]
+	for i, candidate := range b.subs {
+		if candidate.ch == ch {
+			sub = candidate
+			// Copy, so snapshots held by publish stay intact
+			b.subs = append(b.subs[:i:i], b.subs[i+1:]...)
+			break
+		}
+	}
+	b.mu.Unlock()
+
+	if sub != nil {
+		close(sub.done)
+		sub.close()
+	}
+}
+
+// SubscribeWithCancel is like Subscribe but also returns a function that
+// unsubscribes the channel. Calling it more than once has no effect.
+func (b *Broadcaster[T]) SubscribeWithCancel() (<-chan T, func()) {
+	ch := b.Subscribe()
+	return ch, func() { b.Unsubscribe(ch) }
+}
+
+// register adds ch as a subscriber, or closes it if b is closed.
+func (b *Broadcaster[T]) register(ch chan T, skipThrough uint64) <-chan T {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		close(ch)
+		return ch
+	}
+	b.subs = append(b.subs, &subscription[T]{ch: ch, done: make(chan struct{}), skipThrough: skipThrough})
+	return ch
+}
+
+// OnFirstSubscribe sets start to run once, on the first Subscribe, before
+// that subscriber is registered. Publishers use it to start generating
+// lazily. Must be called before b is used.
+func OnFirstSubscribe[T any](b *Broadcaster[T], start func()) {
+	b.start = start
+}
+
+// SubscribeReplay registers a new subscriber channel that first receives
+// current, the state after the seq-th publish. Later PublishSeq calls with
+// a sequence number up to seq are not delivered to it, since current
+// already reflects them. The channel has a buffer of one to hold current
+// without blocking the caller. If b is closed, the channel holds current
+// and is already closed.
+func SubscribeReplay[T any](b *Broadcaster[T], current T, seq uint64) <-chan T {
+	ch := make(chan T, 1)
+	ch <- current
+	return b.register(ch, seq)
+}
+
+// Publish sends value to every subscriber, blocking until each receives it
+// or unsubscribes. Gives up once stop is closed (a nil stop never is);
+// returns false if it did.
+func Publish[T any](b *Broadcaster[T], value T, stop <-chan struct{}) bool {
+	return PublishSeq(b, value, 0, stop)
+}
+
+// PublishSeq is like Publish for the state after the seq-th publish,
+// skipping subscribers that were already replayed that state (see
+// SubscribeReplay). A seq of 0 is delivered to all subscribers.
+func PublishSeq[T any](b *Broadcaster[T], value T, seq uint64, stop <-chan struct{}) bool {
+	b.mu.Lock()
+	subs := b.subs
+	b.mu.Unlock()
+
+	for _, sub := range subs {
+		if !sub.send(value, seq, stop) {
+			return false
+		}
+	}
+	return true
+}
+
+// Close closes all subscriber channels. Later Subscribe calls return an
+// already closed channel.
+func Close[T any](b *Broadcaster[T]) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, sub := range b.subs {
+		sub.close()
+	}
+	b.subs = nil
+	b.closed = true
+}
+
+// Count returns the number of subscribers.
+func Count[T any](b *Broadcaster[T]) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subs)
+}
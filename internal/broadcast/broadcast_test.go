@@ -0,0 +1,60 @@
+package broadcast_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/neox5/simv/internal/broadcast"
+)
+
+// TestUnsubscribe_AbandonsPendingSend verifies a subscriber that stops
+// reading and unsubscribes releases a blocked publish.
+func TestUnsubscribe_AbandonsPendingSend(t *testing.T) {
+	var b broadcast.Broadcaster[int]
+	stalled := b.Subscribe()
+
+	published := make(chan bool)
+	go func() { published <- broadcast.Publish(&b, 1, nil) }()
+
+	time.Sleep(10 * time.Millisecond) // let the send block
+	b.Unsubscribe(stalled)
+
+	select {
+	case ok := <-published:
+		if !ok {
+			t.Error("Publish reported a stop")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Publish still blocked after Unsubscribe")
+	}
+	if _, ok := <-stalled; ok {
+		t.Error("unsubscribed channel delivered a value")
+	}
+	if got := broadcast.Count(&b); got != 0 {
+		t.Errorf("got %d subscribers, want 0", got)
+	}
+}
+
+// TestSubscribe_StartAndClose verifies the start function runs once, on
+// the first Subscribe, and subscribing after Close yields a closed channel.
+func TestSubscribe_StartAndClose(t *testing.T) {
+	var b broadcast.Broadcaster[int]
+	starts := 0
+	broadcast.OnFirstSubscribe(&b, func() { starts++ })
+
+	first, cancel := b.SubscribeWithCancel()
+	b.Subscribe()
+	if starts != 1 {
+		t.Errorf("start ran %d times, want 1", starts)
+	}
+	cancel()
+	cancel()
+	if _, ok := <-first; ok {
+		t.Error("cancelled channel still open")
+	}
+
+	broadcast.Close(&b)
+	if _, ok := <-b.Subscribe(); ok {
+		t.Error("Subscribe after Close returned an open channel")
+	}
+}
@@ -3,6 +3,7 @@ package seed
 import (
 	"math/rand/v2"
 	"sync"
+	"sync/atomic"
 )
 
 var (
@@ -10,11 +11,21 @@ var (
 	registryOnce   sync.Once
 )
 
-// registry provides deterministic seed sequences.
+// registry provides deterministic seed sequences. masterSeed and factory
+// are immutable after Init, so only the stream counter needs
+// synchronization.
 type registry struct {
-	mu         sync.Mutex
 	masterSeed uint64
-	nextStream uint64
+	nextStream atomic.Uint64
+	factory    SourceFactory
+}
+
+// SourceFactory creates the rand.Source for one stream from its seeds.
+type SourceFactory func(seed1, seed2 uint64) rand.Source
+
+// newPCG is the default SourceFactory.
+func newPCG(seed1, seed2 uint64) rand.Source {
+	return rand.NewPCG(seed1, seed2)
 }
 
 // Init initializes the global seed registry with a master seed.
@@ -29,11 +40,35 @@ type registry struct {
 //
 //	seed.Init(uint64(time.Now().UnixNano()))
 func Init(masterSeed uint64) {
+	InitWith(masterSeed, newPCG)
+}
+
+// InitWith initializes the global seed registry like Init, but creates each
+// stream's generator with factory instead of the default PCG.
+// Use it to match another system's RNG output or to pick a generator with
+// specific statistical properties, e.g.:
+//
+//	seed.InitWith(12345, func(s1, s2 uint64) rand.Source {
+//		var key [32]byte
+//		binary.LittleEndian.PutUint64(key[:8], s1)
+//		binary.LittleEndian.PutUint64(key[8:16], s2)
+//		return rand.NewChaCha8(key)
+//	})
+//
+// Shares Init's contract: it must be called before any simv sources are
+// created, and panics if the registry was already initialized.
+// factory may be called concurrently by sources created concurrently.
+// Panics if factory is nil.
+func InitWith(masterSeed uint64, factory SourceFactory) {
+	if factory == nil {
+		panic("seed.InitWith called with nil factory")
+	}
+
 	initialized := false
 	registryOnce.Do(func() {
 		globalRegistry = &registry{
 			masterSeed: masterSeed,
-			nextStream: 0,
+			factory:    factory,
 		}
 		initialized = true
 	})
@@ -44,7 +79,8 @@ func Init(masterSeed uint64) {
 }
 
 // NewRand returns a new independent random number generator.
-// Each call returns an RNG with seeds (masterSeed, streamN) where N increments.
+// Each call returns an RNG with seeds (masterSeed, streamN) where N increments,
+// created by the registry's SourceFactory (PCG unless set via InitWith).
 // Panics if Init() was not called.
 func NewRand() *rand.Rand {
 	if globalRegistry == nil {
@@ -67,19 +103,22 @@ func Current() (masterSeed, streamCounter uint64) {
 		panic("seed.Current called before seed.Init - call seed.Init() at program start")
 	}
 
-	globalRegistry.mu.Lock()
-	defer globalRegistry.mu.Unlock()
+	return globalRegistry.masterSeed, globalRegistry.nextStream.Load()
+}
 
-	return globalRegistry.masterSeed, globalRegistry.nextStream
+// StreamCount returns the number of NewRand() calls made so far, i.e. the
+// stream counter reported by Current. It reads the counter atomically, so
+// frequent calls do not contend with sources being created.
+// Panics if Init() was not called.
+func StreamCount() uint64 {
+	if globalRegistry == nil {
+		panic("seed.StreamCount called before seed.Init - call seed.Init() at program start")
+	}
+	return globalRegistry.nextStream.Load()
 }
 
 func (r *registry) newRand() *rand.Rand {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-
-	seed1 := r.masterSeed
-	seed2 := r.nextStream
-	r.nextStream++
-
-	return rand.New(rand.NewPCG(seed1, seed2))
+	// Each stream number is claimed exactly once
+	seed2 := r.nextStream.Add(1) - 1
+	return rand.New(r.factory(r.masterSeed, seed2))
 }
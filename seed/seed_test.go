@@ -0,0 +1,103 @@
+package seed_test
+
+import (
+	"math/rand/v2"
+	"sync"
+	"testing"
+
+	"github.com/neox5/simv/seed"
+)
+
+// ============================================================================
+// HELPERS
+// ============================================================================
+
+const masterSeed = 42
+
+// streams records the seeds the registry passes to its SourceFactory.
+var streams struct {
+	mu    sync.Mutex
+	seeds [][2]uint64
+}
+
+// TestMain initializes the registry once, with a factory that records its
+// calls; the registry cannot be initialized again within a test binary.
+func TestMain(m *testing.M) {
+	seed.InitWith(masterSeed, func(seed1, seed2 uint64) rand.Source {
+		streams.mu.Lock()
+		defer streams.mu.Unlock()
+		streams.seeds = append(streams.seeds, [2]uint64{seed1, seed2})
+		return rand.NewPCG(seed1, seed2)
+	})
+	m.Run()
+}
+
+// ============================================================================
+// FUNCTIONAL TESTS
+// ============================================================================
+
+// TestInitWith_UsesFactory verifies every NewRand stream comes from the
+// factory with (masterSeed, stream) seeds, and the registry cannot be
+// initialized twice.
+func TestInitWith_UsesFactory(t *testing.T) {
+	streams.mu.Lock()
+	first := len(streams.seeds)
+	streams.mu.Unlock()
+
+	a, b := seed.NewRand(), seed.NewRand()
+
+	streams.mu.Lock()
+	got := append([][2]uint64(nil), streams.seeds[first:]...)
+	streams.mu.Unlock()
+	if len(got) != 2 || got[0][0] != masterSeed || got[1][1] != got[0][1]+1 {
+		t.Fatalf("factory calls: got %v, want two consecutive streams of seed %d", got, masterSeed)
+	}
+	want := rand.New(rand.NewPCG(masterSeed, got[0][1]))
+	if x, y := a.Uint64(), want.Uint64(); x != y {
+		t.Errorf("stream %d: got %d, want %d from the factory source", got[0][1], x, y)
+	}
+	if a.Uint64() == b.Uint64() {
+		t.Error("streams are not independent")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("second InitWith: expected panic")
+		}
+	}()
+	seed.InitWith(1, func(s1, s2 uint64) rand.Source { return rand.NewPCG(s1, s2) })
+}
+
+// TestStreamCount_Concurrent verifies every NewRand call claims exactly
+// one stream, including calls made concurrently, and Current agrees.
+func TestStreamCount_Concurrent(t *testing.T) {
+	const goroutines, perGoroutine = 8, 50
+	before := seed.StreamCount()
+
+	var wg sync.WaitGroup
+	for range goroutines {
+		wg.Go(func() {
+			for range perGoroutine {
+				seed.NewRand()
+			}
+		})
+	}
+	wg.Wait()
+
+	if got := seed.StreamCount() - before; got != goroutines*perGoroutine {
+		t.Errorf("got %d new streams, want %d", got, goroutines*perGoroutine)
+	}
+	if master, count := seed.Current(); master != masterSeed || count != seed.StreamCount() {
+		t.Errorf("Current: got (%d, %d), want (%d, %d)", master, count, masterSeed, seed.StreamCount())
+	}
+
+	streams.mu.Lock()
+	defer streams.mu.Unlock()
+	claimed := make(map[uint64]bool, len(streams.seeds))
+	for _, s := range streams.seeds {
+		if claimed[s[1]] {
+			t.Fatalf("stream %d created twice", s[1])
+		}
+		claimed[s[1]] = true
+	}
+}
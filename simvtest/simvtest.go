@@ -0,0 +1,50 @@
+// Package simvtest provides helpers for testing simv pipelines.
+package simvtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/neox5/simv/value"
+)
+
+// CollectN subscribes to v and returns the next n updates.
+// Returns early with fewer than n updates if timeout expires or v stops.
+// The subscription is removed before returning, so it never blocks v.
+func CollectN[T any](v *value.Value[T], n int, timeout time.Duration) []T {
+	updates := v.Subscribe()
+	defer v.Unsubscribe(updates)
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	got := make([]T, 0, n)
+	for len(got) < n {
+		select {
+		case u, ok := <-updates:
+			if !ok {
+				return got
+			}
+			got = append(got, u)
+		case <-timer.C:
+			return got
+		}
+	}
+	return got
+}
+
+// AssertSequence reports a test error if got differs from want.
+func AssertSequence[T comparable](t testing.TB, got, want []T) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Errorf("got %d values %v, want %d values %v", len(got), got, len(want), want)
+		return
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("value %d: got %v, want %v (got %v, want %v)", i, got[i], want[i], got, want)
+			return
+		}
+	}
+}
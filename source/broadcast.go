@@ -0,0 +1,9 @@
+package source
+
+import "github.com/neox5/simv/internal/broadcast"
+
+// broadcaster is embedded by every source to provide Subscribe,
+// Unsubscribe and SubscribeWithCancel with the semantics documented on
+// broadcast.Broadcaster. Each source registers its start method with
+// broadcast.OnFirstSubscribe, so generating begins on the first Subscribe.
+type broadcaster[T any] = broadcast.Broadcaster[T]
@@ -0,0 +1,124 @@
+package source
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/neox5/simv/internal/broadcast"
+)
+
+// ConflatingSource passes on only the latest upstream value, dropping
+// values that a slow consumer has no time for.
+type ConflatingSource[T any] struct {
+	broadcaster[T]
+
+	upstream Upstream[T]
+
+	// Single-slot latest value, filled by run and emptied by deliver
+	mu      sync.Mutex
+	latest  T
+	pending bool
+	notify  chan struct{} // signals a filled slot; closed with upstream
+
+	upstreamChan    <-chan T
+	generationCount atomic.Uint64
+	conflatedCount  atomic.Uint64
+}
+
+// NewConflatingSource creates a source that always reads upstream without
+// blocking and holds its most recent value in a single slot. The slot is
+// delivered once subscribers are ready; a value replaced before delivery
+// is dropped and counted in SourceStats.ConflatedCount. Consumers thus
+// never work through a backlog: after a burst they receive at most the
+// value already being delivered and then the newest one.
+// All subscribers share the slot, so the slowest one sets the pace.
+// When upstream closes, the pending value is delivered, then subscriber
+// channels are closed.
+func NewConflatingSource[T any](upstream Upstream[T]) *ConflatingSource[T] {
+	s := &ConflatingSource[T]{
+		upstream: upstream,
+		notify:   make(chan struct{}, 1),
+	}
+	broadcast.OnFirstSubscribe(&s.broadcaster, s.start)
+	return s
+}
+
+// start begins generating, on the first Subscribe.
+func (s *ConflatingSource[T]) start() {
+	s.upstreamChan = s.upstream.Subscribe()
+	go s.run()
+	go s.deliver()
+}
+
+// run stores each upstream value in the slot, replacing an undelivered one.
+func (s *ConflatingSource[T]) run() {
+	for value := range s.upstreamChan {
+		s.mu.Lock()
+		if s.pending {
+			s.conflatedCount.Add(1)
+		}
+		s.latest = value
+		s.pending = true
+		s.mu.Unlock()
+
+		select {
+		case s.notify <- struct{}{}:
+		default: // already signalled
+		}
+	}
+
+	// Upstream closed, let deliver finish
+	close(s.notify)
+}
+
+// deliver publishes the slot whenever it is filled.
+func (s *ConflatingSource[T]) deliver() {
+	for {
+		if value, ok := s.take(); ok {
+			s.generationCount.Add(1)
+			broadcast.Publish(&s.broadcaster, value, nil)
+			continue
+		}
+		if _, open := <-s.notify; !open {
+			break
+		}
+	}
+
+	// Deliver a value stored just before upstream closed
+	if value, ok := s.take(); ok {
+		s.generationCount.Add(1)
+		broadcast.Publish(&s.broadcaster, value, nil)
+	}
+	broadcast.Close(&s.broadcaster)
+}
+
+// take empties the slot, returning false if it held no value.
+func (s *ConflatingSource[T]) take() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value, ok := s.latest, s.pending
+	s.pending = false
+	return value, ok
+}
+
+// Errors implements ErrorReporter. This source cannot fail, so the
+// returned channel is already closed.
+func (s *ConflatingSource[T]) Errors() <-chan error {
+	return noErrors
+}
+
+// Stats returns current source metrics.
+func (s *ConflatingSource[T]) Stats() SourceStats {
+	return SourceStats{
+		GenerationCount: s.generationCount.Load(),
+		SubscriberCount: broadcast.Count(&s.broadcaster),
+		ConflatedCount:  s.conflatedCount.Load(),
+	}
+}
+
+// Upstreams returns the conflated upstream, for pipeline introspection
+// (see value.GraphDOT).
+func (s *ConflatingSource[T]) Upstreams() []any {
+	return []any{s.upstream}
+}
@@ -1,45 +1,37 @@
 package source
 
 import (
-	"sync"
 	"sync/atomic"
 
 	"github.com/neox5/simv/clock"
+	"github.com/neox5/simv/internal/broadcast"
 )
 
 // ConstSource always returns the same value.
 type ConstSource[T any] struct {
+	broadcaster[T]
+
 	clock clock.Clock
 	value T
 
-	initOnce        sync.Once
 	clockChan       <-chan struct{}
-	mu              sync.Mutex
-	subscribers     []chan T
 	generationCount atomic.Uint64
 }
 
 // NewConstSource creates a source that always returns the given value.
 func NewConstSource[T any](clk clock.Clock, value T) *ConstSource[T] {
-	return &ConstSource[T]{
+	s := &ConstSource[T]{
 		clock: clk,
 		value: value,
 	}
+	broadcast.OnFirstSubscribe(&s.broadcaster, s.start)
+	return s
 }
 
-// Subscribe returns a channel that receives constant values on each clock tick.
-func (s *ConstSource[T]) Subscribe() <-chan T {
-	s.initOnce.Do(func() {
-		s.clockChan = s.clock.Subscribe()
-		go s.run()
-	})
-
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	ch := make(chan T)
-	s.subscribers = append(s.subscribers, ch)
-	return ch
+// start begins generating, on the first Subscribe.
+func (s *ConstSource[T]) start() {
+	s.clockChan = s.clock.Subscribe()
+	go s.run()
 }
 
 func (s *ConstSource[T]) run() {
@@ -47,31 +39,29 @@ func (s *ConstSource[T]) run() {
 		value := s.value
 		s.generationCount.Add(1)
 
-		s.mu.Lock()
-		subs := s.subscribers
-		s.mu.Unlock()
-
-		for _, subChan := range subs {
-			subChan <- value
-		}
+		broadcast.Publish(&s.broadcaster, value, nil)
 	}
 
 	// Clock closed, close all subscriber channels
-	s.mu.Lock()
-	for _, subChan := range s.subscribers {
-		close(subChan)
-	}
-	s.mu.Unlock()
+	broadcast.Close(&s.broadcaster)
+}
+
+// Errors implements ErrorReporter. This source cannot fail, so the
+// returned channel is already closed.
+func (s *ConstSource[T]) Errors() <-chan error {
+	return noErrors
 }
 
 // Stats returns current source metrics.
 func (s *ConstSource[T]) Stats() SourceStats {
-	s.mu.Lock()
-	subCount := len(s.subscribers)
-	s.mu.Unlock()
-
 	return SourceStats{
 		GenerationCount: s.generationCount.Load(),
-		SubscriberCount: subCount,
+		SubscriberCount: broadcast.Count(&s.broadcaster),
 	}
 }
+
+// Upstreams returns the clock driving this source, for pipeline
+// introspection (see value.GraphDOT).
+func (s *ConstSource[T]) Upstreams() []any {
+	return []any{s.clock}
+}
@@ -0,0 +1,179 @@
+package source
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sync/atomic"
+
+	"github.com/neox5/simv/clock"
+	"github.com/neox5/simv/internal/broadcast"
+	"github.com/neox5/simv/seed"
+)
+
+// Distribution describes a probability distribution a DistSource draws
+// from. Implement it to plug custom distributions into NewDistSource.
+// Sample is only called from the source goroutine, with the source's RNG.
+type Distribution interface {
+	Sample(r *rand.Rand) float64
+}
+
+// validator is implemented by the built-in distributions to check their
+// parameters at construction time.
+type validator interface {
+	validate() error
+}
+
+// Uniform is the continuous uniform distribution on [Min, Max).
+type Uniform struct {
+	Min, Max float64
+}
+
+// Sample draws a value from the distribution.
+func (d Uniform) Sample(r *rand.Rand) float64 {
+	return d.Min + r.Float64()*(d.Max-d.Min)
+}
+
+func (d Uniform) validate() error {
+	if !(d.Min <= d.Max) {
+		return fmt.Errorf("uniform: min %v greater than max %v", d.Min, d.Max)
+	}
+	return nil
+}
+
+// Normal is the normal (Gaussian) distribution.
+type Normal struct {
+	Mean, StdDev float64
+}
+
+// Sample draws a value from the distribution.
+func (d Normal) Sample(r *rand.Rand) float64 {
+	return d.Mean + r.NormFloat64()*d.StdDev
+}
+
+func (d Normal) validate() error {
+	if !(d.StdDev >= 0) {
+		return fmt.Errorf("normal: stddev %v must not be negative", d.StdDev)
+	}
+	return nil
+}
+
+// Exponential is the exponential distribution with the given rate
+// (events per unit), so its mean is 1/Rate. It models waiting times
+// between independent events.
+type Exponential struct {
+	Rate float64
+}
+
+// Sample draws a value from the distribution.
+func (d Exponential) Sample(r *rand.Rand) float64 {
+	return r.ExpFloat64() / d.Rate
+}
+
+func (d Exponential) validate() error {
+	if !(d.Rate > 0) {
+		return fmt.Errorf("exponential: rate %v must be positive", d.Rate)
+	}
+	return nil
+}
+
+// LogNormal is the distribution of exp(X) for X normal with mean Mu and
+// standard deviation Sigma. It models positive, right-skewed quantities
+// such as latencies and file sizes.
+type LogNormal struct {
+	Mu, Sigma float64
+}
+
+// Sample draws a value from the distribution.
+func (d LogNormal) Sample(r *rand.Rand) float64 {
+	return math.Exp(d.Mu + r.NormFloat64()*d.Sigma)
+}
+
+func (d LogNormal) validate() error {
+	if !(d.Sigma >= 0) {
+		return fmt.Errorf("lognormal: sigma %v must not be negative", d.Sigma)
+	}
+	return nil
+}
+
+// DistSource draws values from a probability distribution.
+type DistSource struct {
+	broadcaster[float64]
+
+	clock clock.Clock
+	dist  Distribution
+	rng   *rand.Rand
+
+	clockChan       <-chan struct{}
+	generationCount atomic.Uint64
+}
+
+// NewDistSource creates a source that emits one sample from dist on each
+// clock tick, e.g.:
+//
+//	source.NewDistSource(clk, source.LogNormal{Mu: 3, Sigma: 0.5})
+//
+// Uses the global seed registry for deterministic sequences when seeded.
+// Panics if dist is nil or a built-in distribution has invalid parameters.
+func NewDistSource(clk clock.Clock, dist Distribution) *DistSource {
+	return NewDistSourceWithRand(clk, dist, seed.NewRand())
+}
+
+// NewDistSourceWithRand is like NewDistSource but uses the given RNG.
+// Bypasses the global seed registry, so it does not require seed.Init().
+// The source takes ownership of r; it must not be used concurrently elsewhere.
+func NewDistSourceWithRand(clk clock.Clock, dist Distribution, r *rand.Rand) *DistSource {
+	if dist == nil {
+		panic("dist source: nil distribution")
+	}
+	if v, ok := dist.(validator); ok {
+		if err := v.validate(); err != nil {
+			panic("dist source: " + err.Error())
+		}
+	}
+	s := &DistSource{
+		clock: clk,
+		dist:  dist,
+		rng:   r,
+	}
+	broadcast.OnFirstSubscribe(&s.broadcaster, s.start)
+	return s
+}
+
+// start begins generating, on the first Subscribe.
+func (s *DistSource) start() {
+	s.clockChan = s.clock.Subscribe()
+	go s.run()
+}
+
+func (s *DistSource) run() {
+	for range s.clockChan {
+		value := s.dist.Sample(s.rng)
+		s.generationCount.Add(1)
+
+		broadcast.Publish(&s.broadcaster, value, nil)
+	}
+
+	// Clock closed, close all subscriber channels
+	broadcast.Close(&s.broadcaster)
+}
+
+// Errors implements ErrorReporter. This source cannot fail, so the
+// returned channel is already closed.
+func (s *DistSource) Errors() <-chan error {
+	return noErrors
+}
+
+// Stats returns current source metrics.
+func (s *DistSource) Stats() SourceStats {
+	return SourceStats{
+		GenerationCount: s.generationCount.Load(),
+		SubscriberCount: broadcast.Count(&s.broadcaster),
+	}
+}
+
+// Upstreams returns the clock driving this source, for pipeline
+// introspection (see value.GraphDOT).
+func (s *DistSource) Upstreams() []any {
+	return []any{s.clock}
+}
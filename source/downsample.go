@@ -0,0 +1,133 @@
+package source
+
+import (
+	"math/rand/v2"
+	"sync/atomic"
+
+	"github.com/neox5/simv/internal/broadcast"
+	"github.com/neox5/simv/seed"
+)
+
+// DownsampleSource emits one value per block of upstream values, reducing
+// the rate of a large stream such as a replayed dataset.
+type DownsampleSource[T any] struct {
+	broadcaster[T]
+
+	upstream Upstream[T]
+	factor   int
+	rng      *rand.Rand // nil in every-nth mode
+
+	// Current block (only accessed by run)
+	seen   int
+	choice T
+
+	upstreamChan    <-chan T
+	generationCount atomic.Uint64
+}
+
+// NewDownsampleSource creates a source that emits every factor-th value
+// from upstream (the factor-th, 2·factor-th, ...). Cheap and deterministic,
+// but it can alias with periodic patterns in the data: a cycle whose length
+// divides factor is always sampled at the same phase.
+// Subscriber channels are closed when upstream closes.
+// Panics if factor is not positive.
+func NewDownsampleSource[T any](upstream Upstream[T], factor int) *DownsampleSource[T] {
+	return newDownsampleSource(upstream, factor, nil)
+}
+
+// NewReservoirDownsampleSource creates a source that, for each block of
+// factor consecutive upstream values, emits one chosen uniformly at random
+// (reservoir sampling with a reservoir of one). Every upstream value is
+// emitted with probability 1/factor, so the output is an unbiased sample
+// that preserves the distribution, including percentiles, without the
+// aliasing of NewDownsampleSource. Values are emitted at the end of each
+// block; a final partial block is dropped, as sampling from it would
+// over-represent its values.
+// Uses the global seed registry for deterministic sequences when seeded.
+// Subscriber channels are closed when upstream closes.
+// Panics if factor is not positive.
+func NewReservoirDownsampleSource[T any](upstream Upstream[T], factor int) *DownsampleSource[T] {
+	return NewReservoirDownsampleSourceWithRand(upstream, factor, seed.NewRand())
+}
+
+// NewReservoirDownsampleSourceWithRand is like NewReservoirDownsampleSource
+// but uses the given RNG. Bypasses the global seed registry, so it does
+// not require seed.Init().
+// The source takes ownership of r; it must not be used concurrently elsewhere.
+func NewReservoirDownsampleSourceWithRand[T any](upstream Upstream[T], factor int, r *rand.Rand) *DownsampleSource[T] {
+	return newDownsampleSource(upstream, factor, r)
+}
+
+func newDownsampleSource[T any](upstream Upstream[T], factor int, r *rand.Rand) *DownsampleSource[T] {
+	if factor <= 0 {
+		panic("downsample: factor must be positive")
+	}
+	s := &DownsampleSource[T]{
+		upstream: upstream,
+		factor:   factor,
+		rng:      r,
+	}
+	broadcast.OnFirstSubscribe(&s.broadcaster, s.start)
+	return s
+}
+
+// start begins generating, on the first Subscribe.
+func (s *DownsampleSource[T]) start() {
+	s.upstreamChan = s.upstream.Subscribe()
+	go s.run()
+}
+
+func (s *DownsampleSource[T]) run() {
+	for value := range s.upstreamChan {
+		sample, ok := s.observe(value)
+		if !ok {
+			continue
+		}
+		s.generationCount.Add(1)
+
+		broadcast.Publish(&s.broadcaster, sample, nil)
+	}
+
+	// Upstream closed, close all subscriber channels
+	broadcast.Close(&s.broadcaster)
+}
+
+// observe adds value to the current block. Returns the block's sample and
+// true when the block completes.
+func (s *DownsampleSource[T]) observe(value T) (T, bool) {
+	s.seen++
+	switch {
+	case s.rng == nil:
+		s.choice = value // the last value of the block is emitted
+	case s.rng.IntN(s.seen) == 0:
+		// Replace with probability 1/seen, leaving each value equally likely
+		s.choice = value
+	}
+
+	if s.seen < s.factor {
+		var zero T
+		return zero, false
+	}
+	s.seen = 0
+	return s.choice, true
+}
+
+// Errors implements ErrorReporter. This source cannot fail, so the
+// returned channel is already closed.
+func (s *DownsampleSource[T]) Errors() <-chan error {
+	return noErrors
+}
+
+// Stats returns current source metrics.
+func (s *DownsampleSource[T]) Stats() SourceStats {
+	return SourceStats{
+		GenerationCount: s.generationCount.Load(),
+		SubscriberCount: broadcast.Count(&s.broadcaster),
+	}
+}
+
+// Upstreams returns the downsampled upstream, for pipeline introspection
+// (see value.GraphDOT).
+func (s *DownsampleSource[T]) Upstreams() []any {
+	return []any{s.upstream}
+}
@@ -0,0 +1,36 @@
+package source
+
+// ErrorReporter is implemented by sources that can fail mid-stream.
+// Errors() returns a channel of recoverable errors; the stream keeps
+// running after an error is reported. The channel is closed when the
+// source finishes. It is buffered (errorBufferSize); errors reported while
+// the buffer is full are dropped, but still counted in SourceStats.ErrorCount.
+// Sources that cannot fail return an already closed channel.
+type ErrorReporter interface {
+	Errors() <-chan error
+}
+
+// errorBufferSize is the capacity of source error channels.
+const errorBufferSize = 16
+
+// noErrors is the closed error channel returned by sources that cannot fail.
+var noErrors = func() <-chan error {
+	ch := make(chan error)
+	close(ch)
+	return ch
+}()
+
+// errorChan delivers errors without ever blocking the source.
+type errorChan chan error
+
+func newErrorChan() errorChan {
+	return make(errorChan, errorBufferSize)
+}
+
+// report sends err if there is buffer space, dropping it otherwise.
+func (c errorChan) report(err error) {
+	select {
+	case c <- err:
+	default:
+	}
+}
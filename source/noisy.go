@@ -0,0 +1,86 @@
+package source
+
+import (
+	"math/rand/v2"
+	"sync/atomic"
+
+	"github.com/neox5/simv/internal/broadcast"
+	"github.com/neox5/simv/seed"
+)
+
+// NoisySource adds Gaussian measurement noise to a clean signal.
+type NoisySource struct {
+	broadcaster[float64]
+
+	base   Upstream[float64]
+	stdDev float64
+	rng    *rand.Rand
+
+	baseChan        <-chan float64
+	generationCount atomic.Uint64
+}
+
+// NewNoisySource creates a source that emits each value from base plus
+// zero-mean Gaussian noise with standard deviation noiseStdDev, modeling
+// a clean signal corrupted by measurement noise. It emits whenever base
+// does, so it runs at base's clock.
+// Uses the global seed registry for deterministic sequences when seeded.
+// Subscriber channels are closed when base closes.
+// Panics if noiseStdDev is negative.
+func NewNoisySource(base Upstream[float64], noiseStdDev float64) *NoisySource {
+	return NewNoisySourceWithRand(base, noiseStdDev, seed.NewRand())
+}
+
+// NewNoisySourceWithRand is like NewNoisySource but uses the given RNG.
+// Bypasses the global seed registry, so it does not require seed.Init().
+// The source takes ownership of r; it must not be used concurrently elsewhere.
+func NewNoisySourceWithRand(base Upstream[float64], noiseStdDev float64, r *rand.Rand) *NoisySource {
+	if noiseStdDev < 0 {
+		panic("noisy source: noiseStdDev must not be negative")
+	}
+	s := &NoisySource{
+		base:   base,
+		stdDev: noiseStdDev,
+		rng:    r,
+	}
+	broadcast.OnFirstSubscribe(&s.broadcaster, s.start)
+	return s
+}
+
+// start begins generating, on the first Subscribe.
+func (s *NoisySource) start() {
+	s.baseChan = s.base.Subscribe()
+	go s.run()
+}
+
+func (s *NoisySource) run() {
+	for value := range s.baseChan {
+		noisy := value + s.rng.NormFloat64()*s.stdDev
+		s.generationCount.Add(1)
+
+		broadcast.Publish(&s.broadcaster, noisy, nil)
+	}
+
+	// Base closed, close all subscriber channels
+	broadcast.Close(&s.broadcaster)
+}
+
+// Errors implements ErrorReporter. This source cannot fail, so the
+// returned channel is already closed.
+func (s *NoisySource) Errors() <-chan error {
+	return noErrors
+}
+
+// Stats returns current source metrics.
+func (s *NoisySource) Stats() SourceStats {
+	return SourceStats{
+		GenerationCount: s.generationCount.Load(),
+		SubscriberCount: broadcast.Count(&s.broadcaster),
+	}
+}
+
+// Upstreams returns the base signal, for pipeline introspection
+// (see value.GraphDOT).
+func (s *NoisySource) Upstreams() []any {
+	return []any{s.base}
+}
@@ -1,24 +1,25 @@
 package source
 
 import (
+	"context"
 	"math/rand/v2"
-	"sync"
 	"sync/atomic"
 
 	"github.com/neox5/simv/clock"
+	"github.com/neox5/simv/internal/broadcast"
 	"github.com/neox5/simv/seed"
 )
 
 // RandomIntSource generates random integers within a range [min, max].
 type RandomIntSource struct {
+	broadcaster[int]
+
 	clock    clock.Clock
 	min, max int
 	rng      *rand.Rand
+	ctx      context.Context
 
-	initOnce        sync.Once
 	clockChan       <-chan struct{}
-	mu              sync.Mutex
-	subscribers     []chan int
 	generationCount atomic.Uint64
 }
 
@@ -26,59 +27,83 @@ type RandomIntSource struct {
 // in the inclusive range [min, max].
 // Uses the global seed registry for deterministic sequences when seeded.
 func NewRandomIntSource(clk clock.Clock, min, max int) *RandomIntSource {
-	return &RandomIntSource{
+	return NewRandomIntSourceWithRand(clk, min, max, seed.NewRand())
+}
+
+// NewRandomIntSourceWithRand creates a source that generates random integers
+// in the inclusive range [min, max] using the given RNG.
+// Bypasses the global seed registry, so it does not require seed.Init().
+// The source takes ownership of r; it must not be used concurrently elsewhere.
+func NewRandomIntSourceWithRand(clk clock.Clock, min, max int, r *rand.Rand) *RandomIntSource {
+	s := &RandomIntSource{
 		clock: clk,
 		min:   min,
 		max:   max,
-		rng:   seed.NewRand(),
+		rng:   r,
 	}
+	broadcast.OnFirstSubscribe(&s.broadcaster, s.start)
+	return s
 }
 
-// Subscribe returns a channel that receives random integers on each clock tick.
-func (s *RandomIntSource) Subscribe() <-chan int {
-	s.initOnce.Do(func() {
-		s.clockChan = s.clock.Subscribe()
-		go s.run()
-	})
-
-	s.mu.Lock()
-	defer s.mu.Unlock()
+// NewRandomIntSourceContext creates a source like NewRandomIntSource that
+// stops generating when ctx is cancelled, closing all subscriber channels
+// so downstream values exit.
+func NewRandomIntSourceContext(ctx context.Context, clk clock.Clock, min, max int) *RandomIntSource {
+	s := NewRandomIntSource(clk, min, max)
+	s.ctx = ctx
+	return s
+}
 
-	ch := make(chan int)
-	s.subscribers = append(s.subscribers, ch)
-	return ch
+// start begins generating, on the first Subscribe.
+func (s *RandomIntSource) start() {
+	s.clockChan = s.clock.Subscribe()
+	go s.run()
 }
 
 func (s *RandomIntSource) run() {
-	for range s.clockChan {
+	// Clock closed or context cancelled, close all subscriber channels
+	defer broadcast.Close(&s.broadcaster)
+
+	var cancelled <-chan struct{}
+	if s.ctx != nil {
+		cancelled = s.ctx.Done()
+	}
+
+	for {
+		select {
+		case _, ok := <-s.clockChan:
+			if !ok {
+				return
+			}
+		case <-cancelled:
+			return
+		}
+
 		value := s.min + s.rng.IntN(s.max-s.min+1)
 		s.generationCount.Add(1)
 
-		s.mu.Lock()
-		subs := s.subscribers
-		s.mu.Unlock()
-
-		for _, subChan := range subs {
-			subChan <- value
+		if !broadcast.Publish(&s.broadcaster, value, cancelled) {
+			return
 		}
 	}
+}
 
-	// Clock closed, close all subscriber channels
-	s.mu.Lock()
-	for _, subChan := range s.subscribers {
-		close(subChan)
-	}
-	s.mu.Unlock()
+// Errors implements ErrorReporter. This source cannot fail, so the
+// returned channel is already closed.
+func (s *RandomIntSource) Errors() <-chan error {
+	return noErrors
 }
 
 // Stats returns current source metrics.
 func (s *RandomIntSource) Stats() SourceStats {
-	s.mu.Lock()
-	subCount := len(s.subscribers)
-	s.mu.Unlock()
-
 	return SourceStats{
 		GenerationCount: s.generationCount.Load(),
-		SubscriberCount: subCount,
+		SubscriberCount: broadcast.Count(&s.broadcaster),
 	}
 }
+
+// Upstreams returns the clock driving this source, for pipeline
+// introspection (see value.GraphDOT).
+func (s *RandomIntSource) Upstreams() []any {
+	return []any{s.clock}
+}
@@ -0,0 +1,102 @@
+package source
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/neox5/simv/internal/broadcast"
+)
+
+// RateSource converts a cumulative counter stream into an events/sec rate.
+type RateSource struct {
+	broadcaster[float64]
+
+	counter Upstream[int]
+	window  time.Duration
+
+	counterChan     <-chan int
+	samples         []rateSample // trailing window, oldest first
+	generationCount atomic.Uint64
+}
+
+// rateSample is one observed counter value.
+type rateSample struct {
+	at    time.Time
+	count int
+}
+
+// NewRateSource creates a source that, on each value from counter,
+// emits the event rate per second over the trailing window.
+// counter must emit cumulative counts (e.g. an accumulated value).
+// The rate is computed between the oldest and newest samples in the window;
+// 0 is emitted until the window holds at least two samples.
+// Subscriber channels are closed when counter closes.
+func NewRateSource(counter Upstream[int], window time.Duration) *RateSource {
+	s := &RateSource{
+		counter: counter,
+		window:  window,
+	}
+	broadcast.OnFirstSubscribe(&s.broadcaster, s.start)
+	return s
+}
+
+// start begins generating, on the first Subscribe.
+func (s *RateSource) start() {
+	s.counterChan = s.counter.Subscribe()
+	go s.run()
+}
+
+func (s *RateSource) run() {
+	for count := range s.counterChan {
+		rate := s.observe(time.Now(), count)
+		s.generationCount.Add(1)
+
+		broadcast.Publish(&s.broadcaster, rate, nil)
+	}
+
+	// Upstream closed, close all subscriber channels
+	broadcast.Close(&s.broadcaster)
+}
+
+// observe records a sample, evicts samples older than the window and
+// returns the current rate.
+func (s *RateSource) observe(now time.Time, count int) float64 {
+	s.samples = append(s.samples, rateSample{at: now, count: count})
+
+	cutoff := now.Add(-s.window)
+	evict := 0
+	for evict < len(s.samples)-1 && s.samples[evict].at.Before(cutoff) {
+		evict++
+	}
+	s.samples = s.samples[evict:]
+
+	if len(s.samples) < 2 {
+		return 0
+	}
+	oldest := s.samples[0]
+	elapsed := now.Sub(oldest.at).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(count-oldest.count) / elapsed
+}
+
+// Errors implements ErrorReporter. This source cannot fail, so the
+// returned channel is already closed.
+func (s *RateSource) Errors() <-chan error {
+	return noErrors
+}
+
+// Stats returns current source metrics.
+func (s *RateSource) Stats() SourceStats {
+	return SourceStats{
+		GenerationCount: s.generationCount.Load(),
+		SubscriberCount: broadcast.Count(&s.broadcaster),
+	}
+}
+
+// Upstreams returns the counter this source reads from, for pipeline
+// introspection (see value.GraphDOT).
+func (s *RateSource) Upstreams() []any {
+	return []any{s.counter}
+}
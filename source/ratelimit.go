@@ -0,0 +1,107 @@
+package source
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/neox5/simv/internal/broadcast"
+)
+
+// RateLimitSource caps the rate at which upstream values are passed on,
+// simulating a quota-limited feed.
+type RateLimitSource[T any] struct {
+	broadcaster[T]
+
+	upstream  Upstream[T]
+	perSecond float64
+	burst     float64
+
+	// Token bucket (only accessed by run)
+	tokens float64
+	last   time.Time
+
+	upstreamChan    <-chan T
+	generationCount atomic.Uint64
+	throttledCount  atomic.Uint64
+}
+
+// NewRateLimitSource creates a source that forwards upstream values through
+// a token bucket: tokens refill at perSecond, up to burst, and each
+// forwarded value takes one. Values arriving with no token available are
+// dropped and counted in SourceStats.ThrottledCount, so the upstream clock
+// can keep a fine granularity while the output never exceeds the ceiling.
+// The bucket starts full.
+// Subscriber channels are closed when upstream closes.
+// Panics if perSecond or burst is not positive.
+func NewRateLimitSource[T any](upstream Upstream[T], perSecond float64, burst int) *RateLimitSource[T] {
+	if perSecond <= 0 {
+		panic("rate limit: perSecond must be positive")
+	}
+	if burst <= 0 {
+		panic("rate limit: burst must be positive")
+	}
+	s := &RateLimitSource[T]{
+		upstream:  upstream,
+		perSecond: perSecond,
+		burst:     float64(burst),
+		tokens:    float64(burst),
+	}
+	broadcast.OnFirstSubscribe(&s.broadcaster, s.start)
+	return s
+}
+
+// start begins generating, on the first Subscribe.
+func (s *RateLimitSource[T]) start() {
+	s.upstreamChan = s.upstream.Subscribe()
+	s.last = time.Now()
+	go s.run()
+}
+
+func (s *RateLimitSource[T]) run() {
+	for value := range s.upstreamChan {
+		if !s.allow(time.Now()) {
+			s.throttledCount.Add(1)
+			continue
+		}
+		s.generationCount.Add(1)
+
+		broadcast.Publish(&s.broadcaster, value, nil)
+	}
+
+	// Upstream closed, close all subscriber channels
+	broadcast.Close(&s.broadcaster)
+}
+
+// allow refills the bucket up to now and takes a token if one is available.
+func (s *RateLimitSource[T]) allow(now time.Time) bool {
+	s.tokens += now.Sub(s.last).Seconds() * s.perSecond
+	s.tokens = min(s.tokens, s.burst)
+	s.last = now
+
+	if s.tokens < 1 {
+		return false
+	}
+	s.tokens--
+	return true
+}
+
+// Errors implements ErrorReporter. This source cannot fail, so the
+// returned channel is already closed.
+func (s *RateLimitSource[T]) Errors() <-chan error {
+	return noErrors
+}
+
+// Stats returns current source metrics.
+func (s *RateLimitSource[T]) Stats() SourceStats {
+	return SourceStats{
+		GenerationCount: s.generationCount.Load(),
+		SubscriberCount: broadcast.Count(&s.broadcaster),
+		ThrottledCount:  s.throttledCount.Load(),
+	}
+}
+
+// Upstreams returns the rate-limited upstream, for pipeline introspection
+// (see value.GraphDOT).
+func (s *RateLimitSource[T]) Upstreams() []any {
+	return []any{s.upstream}
+}
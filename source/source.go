@@ -4,6 +4,9 @@ package source
 type SourceStats struct {
 	GenerationCount uint64
 	SubscriberCount int
+	ErrorCount      uint64
+	ThrottledCount  uint64 // values dropped by a rate limit
+	ConflatedCount  uint64 // values replaced by a newer one before delivery
 }
 
 // Publisher provides a subscription interface for typed values.
@@ -11,3 +14,9 @@ type Publisher[T any] interface {
 	Subscribe() <-chan T
 	Stats() SourceStats
 }
+
+// Upstream provides the input stream for sources that derive their values
+// from another publisher, such as another source or a value.
+type Upstream[T any] interface {
+	Subscribe() <-chan T
+}
@@ -0,0 +1,538 @@
+package source_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neox5/simv/clock"
+	"github.com/neox5/simv/seed"
+	"github.com/neox5/simv/source"
+)
+
+// ============================================================================
+// HELPERS
+// ============================================================================
+
+// TestMain seeds the global registry once for the registry-seeded sources.
+func TestMain(m *testing.M) {
+	seed.Init(1)
+	m.Run()
+}
+
+// chanUpstream publishes values sent on its channel.
+type chanUpstream[T any] chan T
+
+func (u chanUpstream[T]) Subscribe() <-chan T { return u }
+
+// manualClock ticks once per send on its channel and stops when closed.
+type manualClock chan struct{}
+
+func (c manualClock) Subscribe() <-chan struct{} { return c }
+func (c manualClock) Start()                     {}
+func (c manualClock) Stop()                      {}
+func (c manualClock) Stats() clock.ClockStats    { return clock.ClockStats{} }
+
+// ============================================================================
+// FUNCTIONAL TESTS
+// ============================================================================
+
+// TestDownsample_EveryNth verifies the factor-th value of each block is
+// emitted and a partial block is dropped.
+func TestDownsample_EveryNth(t *testing.T) {
+	up := make(chanUpstream[int])
+	out := source.NewDownsampleSource[int](up, 3).Subscribe()
+
+	go func() {
+		for i := 1; i <= 8; i++ {
+			up <- i
+		}
+		close(up)
+	}()
+
+	var got []int
+	for v := range out {
+		got = append(got, v)
+	}
+	if len(got) != 2 || got[0] != 3 || got[1] != 6 {
+		t.Errorf("got %v, want [3 6]", got)
+	}
+}
+
+// TestDownsample_ReservoirUnbiased verifies every position within a block
+// is sampled with equal probability.
+func TestDownsample_ReservoirUnbiased(t *testing.T) {
+	const (
+		factor = 4
+		blocks = 40000
+	)
+	up := make(chanUpstream[int])
+	rng := rand.New(rand.NewPCG(1, 2))
+	out := source.NewReservoirDownsampleSourceWithRand[int](up, factor, rng).Subscribe()
+
+	// Each upstream value is its position in the block
+	go func() {
+		for range blocks {
+			for pos := range factor {
+				up <- pos
+			}
+		}
+		close(up)
+	}()
+
+	var counts [factor]int
+	for pos := range out {
+		counts[pos]++
+	}
+
+	// Expect blocks/factor per position; allow ~5 standard deviations
+	want := blocks / factor
+	for pos, n := range counts {
+		if n < want-450 || n > want+450 {
+			t.Errorf("position %d sampled %d times, want about %d (counts %v)", pos, n, want, counts)
+		}
+	}
+}
+
+// TestRateLimit_Burst verifies a burst beyond the bucket size is throttled.
+func TestRateLimit_Burst(t *testing.T) {
+	up := make(chanUpstream[int])
+	src := source.NewRateLimitSource[int](up, 0.001, 3) // effectively no refill
+	out := src.Subscribe()
+
+	go func() {
+		for i := range 8 {
+			up <- i
+		}
+		close(up)
+	}()
+
+	var got []int
+	for v := range out {
+		got = append(got, v)
+	}
+	if len(got) != 3 || got[0] != 0 || got[2] != 2 {
+		t.Errorf("got %v, want first 3 values", got)
+	}
+	if stats := src.Stats(); stats.ThrottledCount != 5 || stats.GenerationCount != 3 {
+		t.Errorf("got throttled=%d generated=%d, want 5 and 3",
+			stats.ThrottledCount, stats.GenerationCount)
+	}
+}
+
+// TestNoisySource_MeanVariance verifies the output is centered on the base
+// signal with the configured noise variance.
+func TestNoisySource_MeanVariance(t *testing.T) {
+	const (
+		n      = 50000
+		base   = 20.0
+		stdDev = 1.5
+	)
+	up := make(chanUpstream[float64])
+	rng := rand.New(rand.NewPCG(1, 2))
+	out := source.NewNoisySourceWithRand(up, stdDev, rng).Subscribe()
+
+	go func() {
+		for range n {
+			up <- base
+		}
+		close(up)
+	}()
+
+	var sum, sumSq float64
+	for v := range out {
+		sum += v
+		sumSq += v * v
+	}
+	mean := sum / n
+	variance := sumSq/n - mean*mean
+
+	if math.Abs(mean-base) > 0.05 {
+		t.Errorf("mean: got %.4f, want %.4f", mean, base)
+	}
+	if want := stdDev * stdDev; math.Abs(variance-want) > 0.05*want {
+		t.Errorf("variance: got %.4f, want %.4f", variance, want)
+	}
+}
+
+// TestDistributions_Mean verifies the built-in distributions sample with
+// their theoretical mean.
+func TestDistributions_Mean(t *testing.T) {
+	const n = 100000
+	for name, tc := range map[string]struct {
+		dist source.Distribution
+		mean float64
+	}{
+		"uniform":     {source.Uniform{Min: 2, Max: 6}, 4},
+		"normal":      {source.Normal{Mean: -3, StdDev: 2}, -3},
+		"exponential": {source.Exponential{Rate: 4}, 0.25},
+		"lognormal":   {source.LogNormal{Mu: 1, Sigma: 0.5}, math.Exp(1 + 0.5*0.5/2)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rng := rand.New(rand.NewPCG(1, 2))
+			var sum float64
+			for range n {
+				sum += tc.dist.Sample(rng)
+			}
+			if got := sum / n; math.Abs(got-tc.mean) > 0.02*math.Max(1, math.Abs(tc.mean)) {
+				t.Errorf("mean: got %.4f, want %.4f", got, tc.mean)
+			}
+		})
+	}
+}
+
+// TestDistSource_InvalidParameters verifies invalid built-in parameters
+// panic at construction.
+func TestDistSource_InvalidParameters(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	for name, dist := range map[string]source.Distribution{
+		"nil":              nil,
+		"uniform reversed": source.Uniform{Min: 5, Max: 1},
+		"normal negative":  source.Normal{StdDev: -1},
+		"exponential zero": source.Exponential{},
+		"lognormal NaN":    source.LogNormal{Sigma: math.NaN()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			source.NewDistSourceWithRand(nil, dist, rng)
+		})
+	}
+}
+
+// TestConflatingSource_FreshAfterBurst verifies a stalled consumer skips
+// the backlog of a burst and then sees the newest value.
+func TestConflatingSource_FreshAfterBurst(t *testing.T) {
+	const n = 100
+	up := make(chanUpstream[int])
+	src := source.NewConflatingSource[int](up)
+	out := src.Subscribe()
+
+	// Not reading out: upstream is still never blocked
+	for i := 1; i <= n; i++ {
+		up <- i
+	}
+	close(up)
+
+	// Wait until the newest value waits in the slot, behind the one value
+	// that is in flight to the stalled consumer
+	deadline := time.Now().Add(time.Second)
+	for stats := src.Stats(); stats.GenerationCount+stats.ConflatedCount != n-1; stats = src.Stats() {
+		if time.Now().After(deadline) {
+			t.Fatalf("burst not absorbed: %+v", stats)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	var got []int
+	for v := range out {
+		got = append(got, v)
+	}
+	if len(got) != 2 || got[1] != n {
+		t.Fatalf("got %v, want the in-flight value followed by the newest value %d", got, n)
+	}
+	if stats := src.Stats(); stats.GenerationCount+stats.ConflatedCount != n {
+		t.Errorf("got %d delivered + %d conflated, want %d in total",
+			stats.GenerationCount, stats.ConflatedCount, n)
+	}
+}
+
+// TestMergeOrdered_ArgumentOrder verifies each round is emitted in argument
+// order whatever order the inputs produce in, a closed input is dropped,
+// and the output closes once every input has closed.
+func TestMergeOrdered_ArgumentOrder(t *testing.T) {
+	a, b, c := make(chanUpstream[int], 3), make(chanUpstream[int], 3), make(chanUpstream[int], 3)
+	src := source.NewMergeOrderedSource[int](a, b, c)
+	out := src.Subscribe()
+
+	// Later inputs are filled first; b closes after its first round
+	c <- 31
+	c <- 32
+	c <- 33
+	close(c)
+	b <- 21
+	close(b)
+	a <- 11
+	a <- 12
+	a <- 13
+	close(a)
+
+	var got []int
+	for v := range out {
+		got = append(got, v)
+	}
+	want := []int{11, 21, 31, 12, 32, 13, 33}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+	if stats := src.Stats(); stats.GenerationCount != uint64(len(want)) {
+		t.Errorf("GenerationCount: got %d, want %d", stats.GenerationCount, len(want))
+	}
+}
+
+// TestReaderSource_ReplaysLines verifies one line is emitted per tick,
+// unparsable lines are skipped and reported, and EOF closes the output.
+func TestReaderSource_ReplaysLines(t *testing.T) {
+	clk := make(manualClock)
+	src := source.NewReaderSource(clk, strings.NewReader("1\nx\n3\n"), strconv.Atoi)
+	out := src.Subscribe()
+
+	go func() {
+		for range 4 { // the fourth tick hits EOF
+			clk <- struct{}{}
+		}
+	}()
+
+	var got []int
+	for v := range out {
+		got = append(got, v)
+	}
+	if len(got) != 2 || got[0] != 1 || got[1] != 3 {
+		t.Errorf("got %v, want [1 3]", got)
+	}
+
+	var errs []error
+	for err := range src.Errors() {
+		errs = append(errs, err)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], strconv.ErrSyntax) || !strings.Contains(errs[0].Error(), "line 2") {
+		t.Errorf("errors: got %v, want one syntax error on line 2", errs)
+	}
+	if stats := src.Stats(); stats.GenerationCount != 2 || stats.ErrorCount != 1 {
+		t.Errorf("stats: got generated=%d errors=%d, want 2 and 1", stats.GenerationCount, stats.ErrorCount)
+	}
+}
+
+// TestRateSource_WindowedRate verifies the rate between counter samples in
+// the window, and 0 until two samples are in it.
+func TestRateSource_WindowedRate(t *testing.T) {
+	up := make(chanUpstream[int])
+	src := source.NewRateSource(up, 50*time.Millisecond)
+	out := src.Subscribe()
+	defer close(up)
+
+	begin := time.Now()
+	up <- 100
+	if got := <-out; got != 0 {
+		t.Errorf("single sample: got %v, want 0", got)
+	}
+	received := time.Now()
+
+	time.Sleep(20 * time.Millisecond)
+	sent := time.Now()
+	up <- 110
+	rate := <-out
+	lower := 10 / time.Since(begin).Seconds()
+	if upper := 10 / sent.Sub(received).Seconds(); rate < lower || rate > upper {
+		t.Errorf("got %.1f events/s, want between %.1f and %.1f", rate, lower, upper)
+	}
+
+	time.Sleep(80 * time.Millisecond) // both samples leave the window
+	up <- 200
+	if got := <-out; got != 0 {
+		t.Errorf("after the window passed: got %v, want 0", got)
+	}
+}
+
+// TestWeightedChoiceSource_Frequencies verifies choices are picked in
+// proportion to their normalized weights and zero weights never are.
+func TestWeightedChoiceSource_Frequencies(t *testing.T) {
+	const n = 8000
+	clk := make(manualClock)
+	src := source.NewWeightedChoiceSource(clk, []string{"a", "b", "c"}, []float64{1, 0, 3})
+	out := src.Subscribe()
+
+	go func() {
+		for range n {
+			clk <- struct{}{}
+		}
+		close(clk)
+	}()
+
+	counts := map[string]int{}
+	for v := range out {
+		counts[v]++
+	}
+	if counts["b"] != 0 {
+		t.Errorf("zero weight: picked %d times", counts["b"])
+	}
+	for choice, want := range map[string]float64{"a": 0.25, "c": 0.75} {
+		if got := float64(counts[choice]) / n; math.Abs(got-want) > 0.02 {
+			t.Errorf("%s: got fraction %.3f, want %.2f", choice, got, want)
+		}
+	}
+}
+
+// TestWeightedChoiceSource_InvalidWeights verifies construction panics on
+// weights that cannot form a distribution.
+func TestWeightedChoiceSource_InvalidWeights(t *testing.T) {
+	for name, weights := range map[string][]float64{
+		"length":   {1},
+		"negative": {2, -1},
+		"all zero": {0, 0},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			source.NewWeightedChoiceSource(make(manualClock), []int{1, 2}, weights)
+		})
+	}
+}
+
+// TestErrorReporter_BufferAndClosed verifies errors beyond the buffer are
+// dropped but counted, the stream keeps running after errors, and sources
+// that cannot fail report an already closed channel.
+func TestErrorReporter_BufferAndClosed(t *testing.T) {
+	const bad = 20
+	clk := make(manualClock)
+	input := strings.Repeat("x\n", bad) + "7\n"
+	src := source.NewReaderSource(clk, strings.NewReader(input), strconv.Atoi)
+	out := src.Subscribe()
+
+	go func() {
+		for range bad + 2 {
+			clk <- struct{}{}
+		}
+	}()
+	var got []int
+	for v := range out {
+		got = append(got, v)
+	}
+	if len(got) != 1 || got[0] != 7 {
+		t.Errorf("got %v, want [7] after the bad lines", got)
+	}
+
+	delivered := 0
+	for range src.Errors() {
+		delivered++
+	}
+	if delivered != 16 || src.Stats().ErrorCount != bad {
+		t.Errorf("got %d delivered, %d counted, want 16 and %d", delivered, src.Stats().ErrorCount, bad)
+	}
+
+	for name, r := range map[string]source.ErrorReporter{
+		"const": source.NewConstSource[int](make(manualClock), 1),
+		"rate":  source.NewRateSource(make(chanUpstream[int]), time.Second),
+	} {
+		select {
+		case _, ok := <-r.Errors():
+			if ok {
+				t.Errorf("%s: received an error", name)
+			}
+		default:
+			t.Errorf("%s: error channel not closed", name)
+		}
+	}
+}
+
+// TestRandomIntSource_ContextCancel verifies values stay in range and the
+// output closes on cancellation while the clock is still running.
+func TestRandomIntSource_ContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	clk := make(manualClock)
+	src := source.NewRandomIntSourceContext(ctx, clk, 3, 5)
+	out := src.Subscribe()
+
+	for range 10 {
+		clk <- struct{}{}
+		if v := <-out; v < 3 || v > 5 {
+			t.Fatalf("got %d, want within [3, 5]", v)
+		}
+	}
+
+	cancel()
+	select {
+	case _, ok := <-out:
+		if ok {
+			t.Error("received a value after cancel")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("output still open after cancel")
+	}
+}
+
+// TestStateSource_Dwell verifies each state is held for its dwell ticks,
+// looping or staying on the last state.
+func TestStateSource_Dwell(t *testing.T) {
+	for name, tc := range map[string]struct {
+		loop bool
+		want string
+	}{
+		"loop":    {true, "aabbbaabbb"},
+		"no loop": {false, "aabbbbbbbb"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			clk := make(manualClock)
+			src := source.NewStateSource(clk, []string{"a", "b"}, []int{2, 3}, tc.loop)
+			out := src.Subscribe()
+
+			var got strings.Builder
+			for range len(tc.want) {
+				clk <- struct{}{}
+				got.WriteString(<-out)
+			}
+			close(clk)
+			if got.String() != tc.want {
+				t.Errorf("got %s, want %s", got.String(), tc.want)
+			}
+			if _, ok := <-out; ok {
+				t.Error("output open after the clock closed")
+			}
+		})
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("zero dwell: expected panic")
+		}
+	}()
+	source.NewStateSource(make(manualClock), []int{1}, []int{0}, false)
+}
+
+// TestTriggerSource_PerEvent verifies gen runs once per trigger event, not
+// before, and the output closes with the trigger.
+func TestTriggerSource_PerEvent(t *testing.T) {
+	trigger := make(chan struct{})
+	n := 0
+	src := source.NewTriggerSource(trigger, func() int { n++; return n * 10 })
+	out := src.Subscribe()
+
+	select {
+	case v := <-out:
+		t.Fatalf("got %d before any event", v)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	go func() {
+		for range 3 {
+			trigger <- struct{}{}
+		}
+		close(trigger)
+	}()
+	var got []int
+	for v := range out {
+		got = append(got, v)
+	}
+	if len(got) != 3 || got[0] != 10 || got[2] != 30 {
+		t.Errorf("got %v, want [10 20 30]", got)
+	}
+	if stats := src.Stats(); stats.GenerationCount != 3 {
+		t.Errorf("GenerationCount: got %d, want 3", stats.GenerationCount)
+	}
+}
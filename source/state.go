@@ -0,0 +1,115 @@
+package source
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/neox5/simv/clock"
+	"github.com/neox5/simv/internal/broadcast"
+)
+
+// StateSource steps through a fixed sequence of states, holding each for
+// a set number of ticks.
+type StateSource[T comparable] struct {
+	broadcaster[T]
+
+	clock  clock.Clock
+	states []T
+	dwell  []int
+	loop   bool
+
+	// Position (only accessed by run)
+	index   int
+	elapsed int
+
+	clockChan       <-chan struct{}
+	generationCount atomic.Uint64
+}
+
+// NewStateSource creates a source that emits states[i] for dwellTicks[i]
+// consecutive clock ticks before advancing to the next state.
+// With loop set, the sequence restarts after the last state; otherwise the
+// last state is emitted on every tick once its dwell time has passed.
+// Panics if states is empty, the lengths differ, or a dwell time is not
+// positive.
+func NewStateSource[T comparable](clk clock.Clock, states []T, dwellTicks []int, loop bool) *StateSource[T] {
+	if len(states) == 0 {
+		panic("state source: no states")
+	}
+	if len(states) != len(dwellTicks) {
+		panic("state source: states and dwellTicks must have the same length")
+	}
+	for i, d := range dwellTicks {
+		if d <= 0 {
+			panic(fmt.Sprintf("state source: dwell time for state %d must be positive, got %d", i, d))
+		}
+	}
+
+	s := &StateSource[T]{
+		clock:  clk,
+		states: append([]T(nil), states...),
+		dwell:  append([]int(nil), dwellTicks...),
+		loop:   loop,
+	}
+	broadcast.OnFirstSubscribe(&s.broadcaster, s.start)
+	return s
+}
+
+// start begins generating, on the first Subscribe.
+func (s *StateSource[T]) start() {
+	s.clockChan = s.clock.Subscribe()
+	go s.run()
+}
+
+func (s *StateSource[T]) run() {
+	for range s.clockChan {
+		value := s.next()
+		s.generationCount.Add(1)
+
+		broadcast.Publish(&s.broadcaster, value, nil)
+	}
+
+	// Clock closed, close all subscriber channels
+	broadcast.Close(&s.broadcaster)
+}
+
+// next returns the state for this tick and advances the position.
+func (s *StateSource[T]) next() T {
+	value := s.states[s.index]
+
+	s.elapsed++
+	if s.elapsed < s.dwell[s.index] {
+		return value
+	}
+
+	switch {
+	case s.index < len(s.states)-1:
+		s.index++
+		s.elapsed = 0
+	case s.loop:
+		s.index = 0
+		s.elapsed = 0
+	}
+	// Without loop, stay on the last state
+	return value
+}
+
+// Errors implements ErrorReporter. This source cannot fail, so the
+// returned channel is already closed.
+func (s *StateSource[T]) Errors() <-chan error {
+	return noErrors
+}
+
+// Stats returns current source metrics.
+func (s *StateSource[T]) Stats() SourceStats {
+	return SourceStats{
+		GenerationCount: s.generationCount.Load(),
+		SubscriberCount: broadcast.Count(&s.broadcaster),
+	}
+}
+
+// Upstreams returns the clock driving this source, for pipeline
+// introspection (see value.GraphDOT).
+func (s *StateSource[T]) Upstreams() []any {
+	return []any{s.clock}
+}
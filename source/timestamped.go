@@ -0,0 +1,67 @@
+package source
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/neox5/simv/internal/broadcast"
+	"github.com/neox5/simv/transform"
+)
+
+// TimestampedSource tags each upstream value with its emission time.
+type TimestampedSource[T any] struct {
+	broadcaster[transform.Timestamped[T]]
+
+	upstream Upstream[T]
+
+	upstreamChan    <-chan T
+	generationCount atomic.Uint64
+}
+
+// NewTimestampedSource creates a source that emits each upstream value
+// wrapped with the time it was received from upstream, for measuring
+// pipeline latency with transform.NewLatencyMeasure. Use
+// transform.NewTimestampedLift to apply ordinary transforms to the payload.
+// Subscriber channels are closed when upstream closes.
+func NewTimestampedSource[T any](upstream Upstream[T]) *TimestampedSource[T] {
+	s := &TimestampedSource[T]{upstream: upstream}
+	broadcast.OnFirstSubscribe(&s.broadcaster, s.start)
+	return s
+}
+
+// start begins generating, on the first Subscribe.
+func (s *TimestampedSource[T]) start() {
+	s.upstreamChan = s.upstream.Subscribe()
+	go s.run()
+}
+
+func (s *TimestampedSource[T]) run() {
+	for value := range s.upstreamChan {
+		s.generationCount.Add(1)
+
+		broadcast.Publish(&s.broadcaster, transform.Timestamped[T]{Value: value, EmittedAt: time.Now()}, nil)
+	}
+
+	// Upstream closed, close all subscriber channels
+	broadcast.Close(&s.broadcaster)
+}
+
+// Errors implements ErrorReporter. This source cannot fail, so the
+// returned channel is already closed.
+func (s *TimestampedSource[T]) Errors() <-chan error {
+	return noErrors
+}
+
+// Stats returns current source metrics.
+func (s *TimestampedSource[T]) Stats() SourceStats {
+	return SourceStats{
+		GenerationCount: s.generationCount.Load(),
+		SubscriberCount: broadcast.Count(&s.broadcaster),
+	}
+}
+
+// Upstreams returns the wrapped source, for pipeline introspection
+// (see value.GraphDOT).
+func (s *TimestampedSource[T]) Upstreams() []any {
+	return []any{s.upstream}
+}
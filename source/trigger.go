@@ -0,0 +1,71 @@
+package source
+
+import (
+	"sync/atomic"
+
+	"github.com/neox5/simv/internal/broadcast"
+)
+
+// TriggerSource generates values on external events instead of clock ticks.
+type TriggerSource[T any] struct {
+	broadcaster[T]
+
+	trigger <-chan struct{}
+	gen     func() T
+
+	generationCount atomic.Uint64
+}
+
+// NewTriggerSource creates a source that emits gen() each time trigger
+// receives, e.g. on real incoming requests, bridging simulations into
+// event-driven systems. gen runs on the source goroutine.
+// Subscriber channels are closed when trigger closes.
+// Panics if gen is nil.
+func NewTriggerSource[T any](trigger <-chan struct{}, gen func() T) *TriggerSource[T] {
+	if gen == nil {
+		panic("trigger source: gen must not be nil")
+	}
+	s := &TriggerSource[T]{
+		trigger: trigger,
+		gen:     gen,
+	}
+	broadcast.OnFirstSubscribe(&s.broadcaster, s.start)
+	return s
+}
+
+// start begins generating, on the first Subscribe.
+func (s *TriggerSource[T]) start() {
+	go s.run()
+}
+
+func (s *TriggerSource[T]) run() {
+	for range s.trigger {
+		value := s.gen()
+		s.generationCount.Add(1)
+
+		broadcast.Publish(&s.broadcaster, value, nil)
+	}
+
+	// Trigger closed, close all subscriber channels
+	broadcast.Close(&s.broadcaster)
+}
+
+// Errors implements ErrorReporter. This source cannot fail, so the
+// returned channel is already closed.
+func (s *TriggerSource[T]) Errors() <-chan error {
+	return noErrors
+}
+
+// Stats returns current source metrics.
+func (s *TriggerSource[T]) Stats() SourceStats {
+	return SourceStats{
+		GenerationCount: s.generationCount.Load(),
+		SubscriberCount: broadcast.Count(&s.broadcaster),
+	}
+}
+
+// Upstreams returns the trigger channel, for pipeline introspection
+// (see value.GraphDOT).
+func (s *TriggerSource[T]) Upstreams() []any {
+	return []any{s.trigger}
+}
@@ -0,0 +1,116 @@
+package source
+
+import (
+	"math/rand/v2"
+	"sort"
+	"sync/atomic"
+
+	"github.com/neox5/simv/clock"
+	"github.com/neox5/simv/internal/broadcast"
+	"github.com/neox5/simv/seed"
+)
+
+// WeightedChoiceSource picks from a discrete set of values with fixed
+// probabilities.
+type WeightedChoiceSource[T any] struct {
+	broadcaster[T]
+
+	clock      clock.Clock
+	choices    []T
+	cumulative []float64 // normalized, last element is 1
+	rng        *rand.Rand
+
+	clockChan       <-chan struct{}
+	generationCount atomic.Uint64
+}
+
+// NewWeightedChoiceSource creates a source that emits one of choices on each
+// clock tick, chosen with probability proportional to its weight.
+// Weights are normalized, so they need not sum to 1.
+// Uses the global seed registry for deterministic sequences when seeded.
+// Panics if choices is empty, the lengths differ, a weight is negative,
+// or all weights are zero.
+func NewWeightedChoiceSource[T any](clk clock.Clock, choices []T, weights []float64) *WeightedChoiceSource[T] {
+	if len(choices) == 0 {
+		panic("weighted choice: no choices")
+	}
+	if len(choices) != len(weights) {
+		panic("weighted choice: choices and weights must have the same length")
+	}
+
+	total := 0.0
+	for _, w := range weights {
+		if w < 0 {
+			panic("weighted choice: weights must not be negative")
+		}
+		total += w
+	}
+	if total == 0 {
+		panic("weighted choice: weights must not all be zero")
+	}
+
+	cumulative := make([]float64, len(weights))
+	sum := 0.0
+	for i, w := range weights {
+		sum += w
+		cumulative[i] = sum / total
+	}
+	cumulative[len(cumulative)-1] = 1 // guard against rounding
+
+	s := &WeightedChoiceSource[T]{
+		clock:      clk,
+		choices:    append([]T(nil), choices...),
+		cumulative: cumulative,
+		rng:        seed.NewRand(),
+	}
+	broadcast.OnFirstSubscribe(&s.broadcaster, s.start)
+	return s
+}
+
+// start begins generating, on the first Subscribe.
+func (s *WeightedChoiceSource[T]) start() {
+	s.clockChan = s.clock.Subscribe()
+	go s.run()
+}
+
+func (s *WeightedChoiceSource[T]) run() {
+	for range s.clockChan {
+		value := s.choose()
+		s.generationCount.Add(1)
+
+		broadcast.Publish(&s.broadcaster, value, nil)
+	}
+
+	// Clock closed, close all subscriber channels
+	broadcast.Close(&s.broadcaster)
+}
+
+// choose picks a value according to the cumulative weights.
+// Zero-weight choices are never picked.
+func (s *WeightedChoiceSource[T]) choose() T {
+	r := s.rng.Float64()
+	i := sort.Search(len(s.cumulative), func(i int) bool {
+		return r < s.cumulative[i]
+	})
+	return s.choices[i]
+}
+
+// Errors implements ErrorReporter. This source cannot fail, so the
+// returned channel is already closed.
+func (s *WeightedChoiceSource[T]) Errors() <-chan error {
+	return noErrors
+}
+
+// Stats returns current source metrics.
+func (s *WeightedChoiceSource[T]) Stats() SourceStats {
+	return SourceStats{
+		GenerationCount: s.generationCount.Load(),
+		SubscriberCount: broadcast.Count(&s.broadcaster),
+	}
+}
+
+// Upstreams returns the clock driving this source, for pipeline
+// introspection (see value.GraphDOT).
+func (s *WeightedChoiceSource[T]) Upstreams() []any {
+	return []any{s.clock}
+}
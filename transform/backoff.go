@@ -0,0 +1,60 @@
+package transform
+
+import "time"
+
+// Backoff models the delay of exponential backoff retry logic.
+type Backoff struct {
+	initial, max time.Duration
+	current      time.Duration // 0 after a success
+}
+
+// NewBackoff creates a transform that tracks the delay before the next
+// retry. Inputs encode attempt outcomes: 0 is a success, any other value
+// a failure. A failure after a success (or at the start) sets the delay to
+// initial; each further consecutive failure doubles it, up to max. A
+// success resets the delay to 0. Apply returns the delay after the
+// outcome, e.g. fed by
+//
+//	source.NewWeightedChoiceSource(clk, []time.Duration{0, 1}, []float64{0.7, 0.3})
+//
+// Panics if initial is not positive or max is less than initial.
+func NewBackoff(initial, max time.Duration) *Backoff {
+	if initial <= 0 {
+		panic("backoff: initial delay must be positive")
+	}
+	if max < initial {
+		panic("backoff: max delay must not be less than initial")
+	}
+	return &Backoff{initial: initial, max: max}
+}
+
+// Apply records the outcome and returns the resulting backoff delay.
+func (t *Backoff) Apply(incoming time.Duration, state State[time.Duration]) time.Duration {
+	switch {
+	case incoming == 0:
+		t.current = 0
+	case t.current == 0:
+		t.current = t.initial
+	case t.current > t.max/2:
+		// Doubling would pass max (or overflow)
+		t.current = t.max
+	default:
+		t.current *= 2
+	}
+	return t.current
+}
+
+// Reset clears the delay, as after a success.
+func (t *Backoff) Reset() {
+	t.current = 0
+}
+
+// Clone returns a new Backoff with the same delays and no failures.
+func (t *Backoff) Clone() Transformation[time.Duration] {
+	return NewBackoff(t.initial, t.max)
+}
+
+// Name returns the transform identifier.
+func (t *Backoff) Name() string {
+	return "Backoff"
+}
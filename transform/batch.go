@@ -0,0 +1,50 @@
+package transform
+
+// BatchSum sums inputs in fixed-size batches.
+type BatchSum[T Numeric] struct {
+	batchSize int
+	count     int
+	sum       T
+}
+
+// NewBatchSum creates a transform that sums inputs in batches of batchSize.
+// Within a batch, each output is the running sum of the batch so far. The
+// batchSize-th input completes the batch: its output is the batch total,
+// shown for that one update, and the next input starts a new batch from
+// zero. For a constant input of 1 and batchSize 3 the outputs are
+// 1, 2, 3, 1, 2, 3, ...
+// Panics if batchSize is not positive.
+func NewBatchSum[T Numeric](batchSize int) *BatchSum[T] {
+	if batchSize <= 0 {
+		panic("batch sum: batchSize must be positive")
+	}
+	return &BatchSum[T]{batchSize: batchSize}
+}
+
+// Apply adds the incoming value to the current batch and returns the
+// batch's running sum.
+func (t *BatchSum[T]) Apply(incoming T, state State[T]) T {
+	if t.count == t.batchSize {
+		t.count = 0
+		t.sum = 0
+	}
+	t.count++
+	t.sum += incoming
+	return t.sum
+}
+
+// Reset discards the current batch.
+func (t *BatchSum[T]) Reset() {
+	t.count = 0
+	t.sum = 0
+}
+
+// Clone returns a new BatchSum with the same batch size and an empty batch.
+func (t *BatchSum[T]) Clone() Transformation[T] {
+	return NewBatchSum[T](t.batchSize)
+}
+
+// Name returns the transform identifier.
+func (t *BatchSum[T]) Name() string {
+	return "BatchSum"
+}
@@ -0,0 +1,94 @@
+package transform
+
+import (
+	"slices"
+	"sync"
+)
+
+// CDF tracks the empirical cumulative distribution of inputs at fixed
+// thresholds, e.g. for SLO simulations ("fraction of requests under
+// 100ms").
+type CDF struct {
+	thresholds []float64
+	primary    int // index of the threshold whose fraction Apply returns
+
+	mu     sync.Mutex
+	counts []uint64 // counts[i]: inputs in (thresholds[i-1], thresholds[i]]
+	total  uint64
+}
+
+// NewCDF creates a transform that records, for each threshold, the
+// fraction of inputs less than or equal to it.
+//
+// A pipeline carries one value, so Apply returns a single fraction: the
+// one for the median threshold, thresholds[len(thresholds)/2]. The full
+// distribution is available out of band via Fractions(), which is safe to
+// call from any goroutine while the value is running.
+// Panics if thresholds is empty or not strictly increasing.
+func NewCDF(thresholds []float64) *CDF {
+	if len(thresholds) == 0 {
+		panic("cdf: no thresholds")
+	}
+	for i := 1; i < len(thresholds); i++ {
+		if !(thresholds[i] > thresholds[i-1]) {
+			panic("cdf: thresholds must be strictly increasing")
+		}
+	}
+	return &CDF{
+		thresholds: slices.Clone(thresholds),
+		primary:    len(thresholds) / 2,
+		counts:     make([]uint64, len(thresholds)),
+	}
+}
+
+// Apply records the incoming value and returns the fraction of inputs at
+// or below the median threshold.
+func (t *CDF) Apply(incoming float64, state State[float64]) float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.total++
+	// First threshold >= incoming; inputs above all thresholds only count
+	// toward the total
+	if i, _ := slices.BinarySearch(t.thresholds, incoming); i < len(t.counts) {
+		t.counts[i]++
+	}
+	return t.fractionLocked(t.primary)
+}
+
+// Fractions returns, for each threshold in order, the fraction of inputs
+// seen so far that were less than or equal to it. All fractions are 0
+// before the first input.
+func (t *CDF) Fractions() []float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	fractions := make([]float64, len(t.thresholds))
+	for i := range fractions {
+		fractions[i] = t.fractionLocked(i)
+	}
+	return fractions
+}
+
+// fractionLocked returns the fraction at or below thresholds[i].
+// Must be called with t.mu held.
+func (t *CDF) fractionLocked(i int) float64 {
+	if t.total == 0 {
+		return 0
+	}
+	var below uint64
+	for _, c := range t.counts[:i+1] {
+		below += c
+	}
+	return float64(below) / float64(t.total)
+}
+
+// Clone returns a new CDF with the same thresholds and no inputs.
+func (t *CDF) Clone() Transformation[float64] {
+	return NewCDF(t.thresholds)
+}
+
+// Name returns the transform identifier.
+func (t *CDF) Name() string {
+	return "CDF"
+}
@@ -0,0 +1,52 @@
+package transform
+
+import "strings"
+
+// Chain is a composite transform that applies its children in sequence.
+type Chain[T any] struct {
+	transforms []Transformation[T]
+	name       string
+}
+
+// NewChain creates a transform that applies ts in order, feeding each
+// output into the next. Every child receives the same state, exactly as
+// if the transforms were added to a value individually.
+func NewChain[T any](ts ...Transformation[T]) *Chain[T] {
+	names := make([]string, len(ts))
+	for i, t := range ts {
+		names[i] = t.Name()
+	}
+
+	return &Chain[T]{
+		transforms: append([]Transformation[T](nil), ts...),
+		name:       strings.Join(names, "+"),
+	}
+}
+
+// Apply runs the incoming value through all child transforms.
+func (t *Chain[T]) Apply(incoming T, state State[T]) T {
+	result := incoming
+	for _, child := range t.transforms {
+		result = child.Apply(result, state)
+	}
+	return result
+}
+
+// Name returns the child names joined by "+".
+func (t *Chain[T]) Name() string {
+	return t.name
+}
+
+// Transforms returns the child transforms in application order.
+func (t *Chain[T]) Transforms() []Transformation[T] {
+	return append([]Transformation[T](nil), t.transforms...)
+}
+
+// Clone returns a new Chain of clones of the children (see CloneOf).
+func (t *Chain[T]) Clone() Transformation[T] {
+	children := make([]Transformation[T], len(t.transforms))
+	for i, child := range t.transforms {
+		children[i] = CloneOf(child)
+	}
+	return NewChain(children...)
+}
@@ -0,0 +1,73 @@
+package transform
+
+import "math"
+
+// Correlation measures the online Pearson correlation between its input
+// and a secondary stream.
+type Correlation struct {
+	other *latest[float64]
+
+	// Welford's online means and co-moments of the paired samples
+	count    int
+	meanX    float64
+	meanY    float64
+	m2X, m2Y float64
+	coMoment float64
+}
+
+// NewCorrelation creates a transform that pairs each input with the most
+// recent value published by other and returns the Pearson correlation
+// coefficient of all pairs so far, in [-1, 1]. Means, variances and the
+// covariance are maintained with Welford's numerically stable online
+// algorithm.
+// Inputs that arrive before other has published are not paired and
+// return 0. The output is also 0 during warm-up, until there are two
+// pairs, and whenever either stream has been constant so far, since the
+// coefficient is undefined without variance.
+// other is subscribed to immediately; see NewSubtractLatest for how the
+// subscription is released on Close.
+func NewCorrelation(other Publisher[float64]) *Correlation {
+	return &Correlation{other: newLatest(other)}
+}
+
+// Apply adds the pair (incoming, latest other value) and returns the
+// correlation coefficient.
+func (t *Correlation) Apply(incoming float64, state State[float64]) float64 {
+	y, ok := t.other.get()
+	if !ok {
+		return 0
+	}
+
+	t.count++
+	n := float64(t.count)
+	dx := incoming - t.meanX
+	dy := y - t.meanY
+	t.meanX += dx / n
+	t.meanY += dy / n
+	t.m2X += dx * (incoming - t.meanX)
+	t.m2Y += dy * (y - t.meanY)
+	t.coMoment += dx * (y - t.meanY)
+
+	if t.count < 2 || t.m2X == 0 || t.m2Y == 0 {
+		return 0
+	}
+	r := t.coMoment / math.Sqrt(t.m2X*t.m2Y)
+	// Rounding can push a perfect correlation just past ±1
+	return max(-1, min(1, r))
+}
+
+// Close stops tracking the secondary stream. Safe to call multiple times.
+func (t *Correlation) Close() {
+	t.other.close()
+}
+
+// Clone returns a new Correlation with its own subscription to the same
+// secondary stream and no samples.
+func (t *Correlation) Clone() Transformation[float64] {
+	return NewCorrelation(t.other.other)
+}
+
+// Name returns the transform identifier.
+func (t *Correlation) Name() string {
+	return "Correlation"
+}
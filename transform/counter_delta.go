@@ -0,0 +1,48 @@
+package transform
+
+// CounterDelta converts a monotonic counter that may reset, e.g. on a
+// process restart, into per-update increases that never go negative.
+type CounterDelta[T Numeric] struct {
+	previous T
+	primed   bool // false until the first Apply after creation or Reset
+}
+
+// NewCounterDelta creates a transform that returns the increase of the
+// incoming counter since the previous input. An input below the previous
+// one is taken as a counter reset: the counter restarted from zero, so the
+// whole new value is the increase, as Prometheus treats counter resets.
+// The first input has no predecessor and yields 0.
+func NewCounterDelta[T Numeric]() *CounterDelta[T] {
+	return &CounterDelta[T]{}
+}
+
+// Apply returns the increase since the previous input.
+func (t *CounterDelta[T]) Apply(incoming T, state State[T]) T {
+	previous, primed := t.previous, t.primed
+	t.previous, t.primed = incoming, true
+
+	switch {
+	case !primed:
+		return 0
+	case incoming < previous:
+		return incoming
+	default:
+		return incoming - previous
+	}
+}
+
+// Reset forgets the previous input, so the next one yields 0.
+func (t *CounterDelta[T]) Reset() {
+	t.previous = 0
+	t.primed = false
+}
+
+// Clone returns a new CounterDelta without a previous input.
+func (t *CounterDelta[T]) Clone() Transformation[T] {
+	return NewCounterDelta[T]()
+}
+
+// Name returns the transform identifier.
+func (t *CounterDelta[T]) Name() string {
+	return "CounterDelta"
+}
@@ -0,0 +1,143 @@
+package transform
+
+import (
+	"math"
+	"math/bits"
+)
+
+// DistinctCount tracks the number of distinct values seen.
+// T is Numeric rather than just comparable because the cardinality is
+// returned as the pipeline value.
+type DistinctCount[T Numeric] struct {
+	seen     map[T]struct{}
+	hll      *hyperLogLog // nil in exact mode
+	rounding RoundingMode // for the HLL estimate
+}
+
+// NewDistinctCount creates a transform that returns the exact number of
+// distinct values seen so far. Memory grows with the cardinality.
+func NewDistinctCount[T Numeric]() *DistinctCount[T] {
+	return &DistinctCount[T]{
+		seen: make(map[T]struct{}),
+	}
+}
+
+// NewDistinctCountHLL creates a transform that estimates the number of
+// distinct values with HyperLogLog, using 2^precision bytes of memory
+// regardless of cardinality. The standard error is about
+// 1.04/sqrt(2^precision), e.g. 0.8% for precision 14.
+// Hashing is deterministic, so estimates are reproducible across runs.
+// The estimate is computed in float64; for integer T it is truncated
+// toward zero by default. Use WithRounding to choose a different
+// RoundingMode.
+// Panics if precision is outside [4, 16].
+func NewDistinctCountHLL[T Numeric](precision uint8) *DistinctCount[T] {
+	if precision < 4 || precision > 16 {
+		panic("distinct count: precision must be in [4, 16]")
+	}
+	return &DistinctCount[T]{
+		hll: newHyperLogLog(precision),
+	}
+}
+
+// WithRounding sets how HyperLogLog estimates are converted to integer T.
+// Has no effect for float T or in exact mode, whose counts are integral.
+// Returns the transform for method chaining.
+func (t *DistinctCount[T]) WithRounding(mode RoundingMode) *DistinctCount[T] {
+	t.rounding = mode
+	return t
+}
+
+// Apply records the incoming value and returns the current cardinality.
+func (t *DistinctCount[T]) Apply(incoming T, state State[T]) T {
+	if t.hll != nil {
+		t.hll.add(math.Float64bits(float64(incoming)))
+		return Convert[T](t.hll.estimate(), t.rounding)
+	}
+
+	t.seen[incoming] = struct{}{}
+	return T(len(t.seen))
+}
+
+// Reset forgets all values seen so far.
+func (t *DistinctCount[T]) Reset() {
+	if t.hll != nil {
+		t.hll = newHyperLogLog(t.hll.precision)
+		return
+	}
+	t.seen = make(map[T]struct{})
+}
+
+// Clone returns a new DistinctCount in the same mode with nothing seen.
+func (t *DistinctCount[T]) Clone() Transformation[T] {
+	if t.hll != nil {
+		return NewDistinctCountHLL[T](t.hll.precision).WithRounding(t.rounding)
+	}
+	return NewDistinctCount[T]()
+}
+
+// Name returns the transform identifier.
+func (t *DistinctCount[T]) Name() string {
+	return "DistinctCount"
+}
+
+// hyperLogLog is a cardinality estimator over 64-bit keys.
+// The harmonic sum and zero count are maintained incrementally so that
+// estimate is O(1).
+type hyperLogLog struct {
+	precision uint8
+	registers []uint8
+	sum       float64 // sum of 2^-register
+	zeros     int     // registers still zero
+}
+
+func newHyperLogLog(precision uint8) *hyperLogLog {
+	m := 1 << precision
+	return &hyperLogLog{
+		precision: precision,
+		registers: make([]uint8, m),
+		sum:       float64(m),
+		zeros:     m,
+	}
+}
+
+// add records a key.
+func (h *hyperLogLog) add(key uint64) {
+	hash := mix64(key)
+	idx := hash >> (64 - h.precision)
+	rest := hash<<h.precision | 1<<(h.precision-1) // guard bit bounds the rank
+	rank := uint8(bits.LeadingZeros64(rest)) + 1
+	old := h.registers[idx]
+	if rank <= old {
+		return
+	}
+	if old == 0 {
+		h.zeros--
+	}
+	h.sum += math.Ldexp(1, -int(rank)) - math.Ldexp(1, -int(old))
+	h.registers[idx] = rank
+}
+
+// estimate returns the estimated cardinality.
+func (h *hyperLogLog) estimate() float64 {
+	m := float64(len(h.registers))
+
+	alpha := 0.7213 / (1 + 1.079/m)
+	est := alpha * m * m / h.sum
+
+	// Small range correction: linear counting
+	if est <= 2.5*m && h.zeros > 0 {
+		est = m * math.Log(m/float64(h.zeros))
+	}
+	return est
+}
+
+// mix64 is the SplitMix64 finalizer, used as a deterministic hash.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
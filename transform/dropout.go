@@ -0,0 +1,60 @@
+package transform
+
+import (
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/neox5/simv/seed"
+)
+
+// Dropout randomly replaces inputs with a sentinel to simulate missing data.
+type Dropout[T any] struct {
+	probability float64
+	dropValue   T
+	rng         *rand.Rand
+}
+
+// NewDropout creates a transform that, on each Apply, replaces the
+// incoming value with dropValue with the given probability, and passes it
+// through unchanged otherwise. Use it to check that downstream transforms
+// and consumers cope with sensor dropouts.
+// Uses the global seed registry, so dropout patterns reproduce when seeded.
+// Panics if probability is outside [0, 1].
+func NewDropout[T any](probability float64, dropValue T) *Dropout[T] {
+	return NewDropoutWithRand(probability, dropValue, seed.NewRand())
+}
+
+// NewDropoutWithRand creates a Dropout transform using the given RNG.
+// Bypasses the global seed registry, so it does not require seed.Init().
+// The transform takes ownership of r; it must not be used concurrently
+// elsewhere.
+// Panics if probability is outside [0, 1].
+func NewDropoutWithRand[T any](probability float64, dropValue T, r *rand.Rand) *Dropout[T] {
+	if !(probability >= 0 && probability <= 1) {
+		panic(fmt.Sprintf("dropout: probability must be in [0, 1], got %v", probability))
+	}
+	return &Dropout[T]{
+		probability: probability,
+		dropValue:   dropValue,
+		rng:         r,
+	}
+}
+
+// Apply returns dropValue with the configured probability, else incoming.
+func (t *Dropout[T]) Apply(incoming T, state State[T]) T {
+	if t.rng.Float64() < t.probability {
+		return t.dropValue
+	}
+	return incoming
+}
+
+// Clone returns a new Dropout with the same settings and its own random
+// stream from the global seed registry (which requires seed.Init()).
+func (t *Dropout[T]) Clone() Transformation[T] {
+	return NewDropout(t.probability, t.dropValue)
+}
+
+// Name returns the transform identifier.
+func (t *Dropout[T]) Name() string {
+	return "Dropout"
+}
@@ -0,0 +1,36 @@
+package transform
+
+// FieldAccumulate adds each value to a running total using a caller-supplied
+// addition, for types without a + operator such as structs.
+type FieldAccumulate[T any] struct {
+	add func(a, b T) T
+}
+
+// NewFieldAccumulate creates a transform that accumulates values like
+// Accumulate, combining the current total and each input with add.
+// For a struct, add typically sums field by field:
+//
+//	type Vec struct{ X, Y float64 }
+//
+//	pos := transform.NewFieldAccumulate(func(a, b Vec) Vec {
+//		return Vec{a.X + b.X, a.Y + b.Y}
+//	})
+//
+// The total starts from T's zero value (or Value.SetInitial).
+// Panics if add is nil.
+func NewFieldAccumulate[T any](add func(a, b T) T) *FieldAccumulate[T] {
+	if add == nil {
+		panic("field accumulate: add must not be nil")
+	}
+	return &FieldAccumulate[T]{add: add}
+}
+
+// Apply adds the incoming value to the current state and returns the new total.
+func (t *FieldAccumulate[T]) Apply(incoming T, state State[T]) T {
+	return t.add(state.GetState(), incoming)
+}
+
+// Name returns the transform identifier.
+func (t *FieldAccumulate[T]) Name() string {
+	return "FieldAccumulate"
+}
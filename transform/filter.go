@@ -0,0 +1,38 @@
+package transform
+
+// Filter drops inputs that do not match a predicate, holding the last kept
+// value.
+type Filter[T any] struct {
+	name string
+	keep func(T) bool
+}
+
+// NewFilter creates a transform that passes the incoming value through if
+// keep returns true, and otherwise returns the current state, so the value
+// holds the last kept input across runs of dropped ones.
+// name describes the predicate (e.g. "positive") and appears in traces.
+//
+// Because a dropped input becomes the current state, Filter belongs at the
+// end of a pipeline: before an accumulating transform it would add the
+// state to itself. To exclude inputs from an accumulation, map them to
+// zero instead, or use Value.SetGate.
+// Panics if keep is nil.
+func NewFilter[T any](name string, keep func(T) bool) *Filter[T] {
+	if keep == nil {
+		panic("filter: keep must not be nil")
+	}
+	return &Filter[T]{name: name, keep: keep}
+}
+
+// Apply returns incoming if it is kept, else the current state.
+func (t *Filter[T]) Apply(incoming T, state State[T]) T {
+	if t.keep(incoming) {
+		return incoming
+	}
+	return state.GetState()
+}
+
+// Name returns the transform identifier, including the predicate name.
+func (t *Filter[T]) Name() string {
+	return "Filter(" + t.name + ")"
+}
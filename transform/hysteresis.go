@@ -0,0 +1,53 @@
+package transform
+
+// Hysteresis switches between two output levels using separate thresholds,
+// preventing chatter when the input hovers around a single threshold.
+type Hysteresis[T Numeric] struct {
+	low, high T
+	onValue   T
+	offValue  T
+	on        bool
+}
+
+// NewHysteresis creates a transform that outputs onValue once the input
+// exceeds high, and keeps doing so until the input drops below low, at
+// which point it outputs offValue until the input exceeds high again.
+// Starts in the off state.
+// Panics if low is not less than high.
+func NewHysteresis[T Numeric](low, high T, onValue, offValue T) *Hysteresis[T] {
+	if !(low < high) {
+		panic("hysteresis: low must be less than high")
+	}
+	return &Hysteresis[T]{
+		low:      low,
+		high:     high,
+		onValue:  onValue,
+		offValue: offValue,
+	}
+}
+
+// Apply updates the on/off state from the incoming value and returns the
+// corresponding output level.
+func (t *Hysteresis[T]) Apply(incoming T, state State[T]) T {
+	switch {
+	case !t.on && incoming > t.high:
+		t.on = true
+	case t.on && incoming < t.low:
+		t.on = false
+	}
+
+	if t.on {
+		return t.onValue
+	}
+	return t.offValue
+}
+
+// Clone returns a new Hysteresis with the same thresholds, in the off state.
+func (t *Hysteresis[T]) Clone() Transformation[T] {
+	return NewHysteresis(t.low, t.high, t.onValue, t.offValue)
+}
+
+// Name returns the transform identifier.
+func (t *Hysteresis[T]) Name() string {
+	return "Hysteresis"
+}
@@ -0,0 +1,78 @@
+package transform
+
+import "sync"
+
+// Publisher provides a subscription interface for typed values.
+type Publisher[T any] interface {
+	Subscribe() <-chan T
+}
+
+// unsubscriber is implemented by publishers that support Unsubscribe.
+type unsubscriber[T any] interface {
+	Unsubscribe(ch <-chan T)
+}
+
+// latest tracks the most recent value of a secondary stream for
+// transforms that combine it with their input.
+type latest[T any] struct {
+	mu    sync.Mutex
+	value T
+	seen  bool // other has published at least once
+
+	other     Publisher[T]
+	ch        <-chan T
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// newLatest subscribes to other and tracks it from a background goroutine.
+func newLatest[T any](other Publisher[T]) *latest[T] {
+	l := &latest[T]{
+		other: other,
+		ch:    other.Subscribe(),
+		done:  make(chan struct{}),
+	}
+	go l.track()
+	return l
+}
+
+// track records values from other until it closes or close is called.
+func (l *latest[T]) track() {
+	for {
+		select {
+		case v, ok := <-l.ch:
+			if !ok {
+				return
+			}
+			l.mu.Lock()
+			l.value = v
+			l.seen = true
+			l.mu.Unlock()
+		case <-l.done:
+			if _, ok := l.other.(unsubscriber[T]); !ok {
+				for range l.ch {
+				}
+			}
+			return
+		}
+	}
+}
+
+// get returns the latest value, and false if other has not published yet.
+func (l *latest[T]) get() (T, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.value, l.seen
+}
+
+// close stops tracking: unsubscribes from other if it supports
+// Unsubscribe, else discards its values until it closes, so it is never
+// blocked. Safe to call multiple times.
+func (l *latest[T]) close() {
+	l.closeOnce.Do(func() {
+		if u, ok := l.other.(unsubscriber[T]); ok {
+			u.Unsubscribe(l.ch)
+		}
+		close(l.done)
+	})
+}
@@ -0,0 +1,101 @@
+package transform
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sort"
+
+	"github.com/neox5/simv/seed"
+)
+
+// markovRowTolerance is the allowed deviation of a row sum from 1.
+const markovRowTolerance = 1e-6
+
+// Markov moves between discrete states according to a transition matrix.
+// The incoming value is ignored: each Apply is one tick-driven transition
+// from the current state.
+type Markov[T comparable] struct {
+	rows map[T][]markovEdge[T]
+	rng  *rand.Rand
+}
+
+// markovEdge is one outgoing transition with its cumulative probability.
+type markovEdge[T comparable] struct {
+	to         T
+	cumulative float64
+}
+
+// NewMarkov creates a transform that, on each Apply, picks the next state
+// from the transition distribution of the current state.
+// matrix[from][to] is the probability of moving from one state to another.
+// If the current state has no row (e.g. the zero value before the first
+// update), the incoming value is used as the state, so the source seeds
+// the initial state.
+// Uses the global seed registry for deterministic sequences.
+// Panics if a probability is negative or a row does not sum to 1.
+func NewMarkov[T comparable](matrix map[T]map[T]float64) *Markov[T] {
+	rows := make(map[T][]markovEdge[T], len(matrix))
+
+	for from, row := range matrix {
+		// Fix iteration order so seeded runs are reproducible
+		targets := make([]T, 0, len(row))
+		for to := range row {
+			targets = append(targets, to)
+		}
+		sort.Slice(targets, func(i, j int) bool {
+			return fmt.Sprint(targets[i]) < fmt.Sprint(targets[j])
+		})
+
+		edges := make([]markovEdge[T], 0, len(targets))
+		sum := 0.0
+		for _, to := range targets {
+			p := row[to]
+			if p < 0 {
+				panic(fmt.Sprintf("markov: negative probability %v for %v -> %v", p, from, to))
+			}
+			sum += p
+			edges = append(edges, markovEdge[T]{to: to, cumulative: sum})
+		}
+		if math.Abs(sum-1) > markovRowTolerance {
+			panic(fmt.Sprintf("markov: transitions from %v sum to %v, want 1", from, sum))
+		}
+		rows[from] = edges
+	}
+
+	return &Markov[T]{
+		rows: rows,
+		rng:  seed.NewRand(),
+	}
+}
+
+// Apply returns the next state chosen from the current state's transitions.
+func (t *Markov[T]) Apply(incoming T, state State[T]) T {
+	edges, ok := t.rows[state.GetState()]
+	if !ok {
+		return incoming
+	}
+
+	r := t.rng.Float64()
+	for _, e := range edges {
+		if r < e.cumulative {
+			return e.to
+		}
+	}
+	// Guard against rounding: cumulative may end slightly below 1
+	return edges[len(edges)-1].to
+}
+
+// Clone returns a new Markov with the same transition matrix and its own
+// random stream from the global seed registry.
+func (t *Markov[T]) Clone() Transformation[T] {
+	return &Markov[T]{
+		rows: t.rows, // immutable after construction
+		rng:  seed.NewRand(),
+	}
+}
+
+// Name returns the transform identifier.
+func (t *Markov[T]) Name() string {
+	return "Markov"
+}
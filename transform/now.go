@@ -0,0 +1,31 @@
+package transform
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// nowFunc holds the time source for time-based transforms, nil for time.Now.
+var nowFunc atomic.Pointer[func() time.Time]
+
+// SetNowFunc replaces the time source used by time-based transforms such
+// as WindowedSum, so they can be driven deterministically in tests, e.g.
+// from a fake clock advanced by the test. Pass nil to restore time.Now,
+// the default. The setting is package-wide and affects transforms that
+// are already running; set it before starting values and restore it when
+// done (t.Cleanup(func() { transform.SetNowFunc(nil) })).
+func SetNowFunc(fn func() time.Time) {
+	if fn == nil {
+		nowFunc.Store(nil)
+		return
+	}
+	nowFunc.Store(&fn)
+}
+
+// now returns the current time from the configured time source.
+func now() time.Time {
+	if fn := nowFunc.Load(); fn != nil {
+		return (*fn)()
+	}
+	return time.Now()
+}
@@ -0,0 +1,95 @@
+package transform
+
+import (
+	"math"
+	"time"
+)
+
+// PID is a proportional-integral-derivative controller.
+type PID struct {
+	kp, ki, kd float64
+	setpoint   float64
+	step       time.Duration // fixed time step; 0 measures elapsed time
+
+	// Controller state (only accessed by Apply)
+	integral  float64
+	prevError float64
+	last      time.Time // previous Apply, when measuring elapsed time
+	primed    bool      // prevError and last are valid
+}
+
+// NewPID creates a transform that outputs the control signal of a PID
+// controller driving its input toward setpoint:
+//
+//	kp*e + ki*∫e dt + kd*de/dt, where e = setpoint - input
+//
+// The time step is the time elapsed since the previous Apply, read from
+// the transform time source (see SetNowFunc); use WithTimeStep to fix it
+// to the clock interval instead. The first Apply has no previous input, so
+// it contributes neither integral nor derivative.
+// Panics if a gain is negative or not finite.
+func NewPID(kp, ki, kd, setpoint float64) *PID {
+	for _, gain := range []float64{kp, ki, kd} {
+		if gain < 0 || math.IsInf(gain, 0) || math.IsNaN(gain) {
+			panic("pid: gains must be finite and non-negative")
+		}
+	}
+	return &PID{kp: kp, ki: ki, kd: kd, setpoint: setpoint}
+}
+
+// WithTimeStep fixes the time step of every Apply after the first to step,
+// typically the interval of the clock driving the source, so the output
+// does not depend on scheduling jitter and runs are reproducible.
+// Returns the transform for method chaining.
+// Panics if step is not positive.
+func (t *PID) WithTimeStep(step time.Duration) *PID {
+	if step <= 0 {
+		panic("pid: time step must be positive")
+	}
+	t.step = step
+	return t
+}
+
+// Apply updates the controller from the incoming measurement and returns
+// its output.
+func (t *PID) Apply(incoming float64, state State[float64]) float64 {
+	e := t.setpoint - incoming
+
+	var dt float64
+	if t.step > 0 {
+		dt = t.step.Seconds()
+	} else {
+		at := now()
+		dt = at.Sub(t.last).Seconds()
+		t.last = at
+	}
+
+	output := t.kp * e
+	if t.primed && dt > 0 {
+		t.integral += e * dt
+		output += t.ki*t.integral + t.kd*(e-t.prevError)/dt
+	}
+	t.prevError = e
+	t.primed = true
+
+	return output
+}
+
+// Reset clears the integral and derivative state.
+func (t *PID) Reset() {
+	t.integral = 0
+	t.prevError = 0
+	t.last = time.Time{}
+	t.primed = false
+}
+
+// Clone returns a new PID with the same gains, setpoint and time step and
+// cleared state.
+func (t *PID) Clone() Transformation[float64] {
+	return &PID{kp: t.kp, ki: t.ki, kd: t.kd, setpoint: t.setpoint, step: t.step}
+}
+
+// Name returns the transform identifier.
+func (t *PID) Name() string {
+	return "PID"
+}
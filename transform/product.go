@@ -0,0 +1,81 @@
+package transform
+
+// AccumulateProduct multiplies each value into a running product, e.g. to
+// compound per-period growth multipliers.
+type AccumulateProduct[T Numeric] struct {
+	primed     bool // false until the first Apply after creation or Reset
+	restarted  bool // Reset was called: the next Apply ignores the state
+	onOverflow func()
+}
+
+// NewAccumulateProduct creates a transform that multiplies the incoming
+// value into the current state and returns the new product.
+// The first Apply multiplies the pipeline state, so a SetInitial baseline
+// such as a starting balance is compounded, unless the state is zero: a
+// zero state before the first update would pin the product at 0, so the
+// product then starts from the multiplicative identity 1. Later applies
+// always multiply the state. A value resetting the state to 0 would stop
+// the product for good, so Start() rejects it combined with reset-on-read
+// to a zero reset value; reset to 1 instead.
+// Integer products wrap around on overflow like Accumulate; use
+// OnOverflow to observe it, or a float type for growth that would exceed
+// the integer range.
+func NewAccumulateProduct[T Numeric]() *AccumulateProduct[T] {
+	return &AccumulateProduct[T]{}
+}
+
+// OnOverflow registers fn to be called whenever a multiplication wraps
+// around. The wrapped product is still returned; fn only observes the
+// overflow. fn runs synchronously in Apply, on the update goroutine, so it
+// should be cheap. Only applies to integer T: float products never wrap
+// (they become ±Inf), so fn is never called for them.
+// Returns the transform for method chaining.
+func (t *AccumulateProduct[T]) OnOverflow(fn func()) *AccumulateProduct[T] {
+	t.onOverflow = fn
+	return t
+}
+
+// Apply multiplies the current state by the incoming value and returns
+// the new product.
+func (t *AccumulateProduct[T]) Apply(incoming T, state State[T]) T {
+	current := state.GetState()
+	if t.restarted || !t.primed && current == 0 {
+		current = 1
+	}
+	t.primed, t.restarted = true, false
+
+	product := current * incoming
+	if t.onOverflow != nil && !isFloat[T]() && productWrapped(current, incoming, product) {
+		t.onOverflow()
+	}
+	return product
+}
+
+// productWrapped reports whether the integer product p = a*b wrapped.
+func productWrapped[T Numeric](a, b, p T) bool {
+	if a == 0 || b == 0 {
+		return false
+	}
+	// Division catches lost magnitude; the sign check catches minInt * -1,
+	// whose quotient wraps back to b
+	negative := (a < 0) != (b < 0)
+	return p/a != b || (p < 0) != negative
+}
+
+// Reset makes the next Apply start again from the multiplicative identity,
+// whatever the state.
+func (t *AccumulateProduct[T]) Reset() {
+	t.primed = false
+	t.restarted = true
+}
+
+// Clone returns a new AccumulateProduct with the same overflow callback,
+// not yet applied.
+func (t *AccumulateProduct[T]) Clone() Transformation[T] {
+	return &AccumulateProduct[T]{onOverflow: t.onOverflow}
+}
+
+// Name returns the transform identifier.
+func (t *AccumulateProduct[T]) Name() string {
+	return "AccumulateProduct"
+}
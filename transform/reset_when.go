@@ -0,0 +1,60 @@
+package transform
+
+// ResetWhen resets the pipeline value when a predicate on the state holds.
+// Intended to follow Accumulate to model batch boundaries.
+type ResetWhen[T Numeric] struct {
+	predicate func(state T) bool
+	resetTo   T
+	before    bool
+}
+
+// NewResetWhen creates a transform that resets after the current input is
+// applied: if predicate holds for the incoming value (the new state
+// produced by the preceding transforms), resetTo is returned instead.
+// The state that triggered the reset is never observable.
+//
+// Example: Accumulate followed by NewResetWhen(func(s int) bool { return s >= 10 }, 0)
+// turns inputs 4, 4, 4, 4 into 4, 8, 0, 4.
+func NewResetWhen[T Numeric](predicate func(state T) bool, resetTo T) *ResetWhen[T] {
+	return &ResetWhen[T]{
+		predicate: predicate,
+		resetTo:   resetTo,
+	}
+}
+
+// NewResetWhenBefore creates a transform that resets before the current
+// input is applied: if predicate holds for the previous state, the incoming
+// value is rebased from the previous state onto resetTo
+// (incoming - previous + resetTo). Following Accumulate, this restarts the
+// total from resetTo plus the current input, so the state that triggered
+// the reset stays observable for one update.
+//
+// Example: Accumulate followed by NewResetWhenBefore(func(s int) bool { return s >= 10 }, 0)
+// turns inputs 4, 4, 4, 4 into 4, 8, 12, 4.
+func NewResetWhenBefore[T Numeric](predicate func(state T) bool, resetTo T) *ResetWhen[T] {
+	return &ResetWhen[T]{
+		predicate: predicate,
+		resetTo:   resetTo,
+		before:    true,
+	}
+}
+
+// Apply returns the incoming value, reset according to the predicate.
+func (t *ResetWhen[T]) Apply(incoming T, state State[T]) T {
+	if t.before {
+		if previous := state.GetState(); t.predicate(previous) {
+			return incoming - previous + t.resetTo
+		}
+		return incoming
+	}
+
+	if t.predicate(incoming) {
+		return t.resetTo
+	}
+	return incoming
+}
+
+// Name returns the transform identifier.
+func (t *ResetWhen[T]) Name() string {
+	return "ResetWhen"
+}
@@ -0,0 +1,80 @@
+package transform
+
+import (
+	"fmt"
+	"math"
+)
+
+// RoundingMode controls how transforms that compute in float64 convert
+// results to integer types. Float types are never rounded.
+// Every built-in transform with such results takes it via WithRounding:
+// UnitScale and the HyperLogLog mode of DistinctCount. The other integer
+// transforms compute in T itself, so they never convert from float64.
+type RoundingMode int
+
+const (
+	// RoundTruncate truncates toward zero (2.7 -> 2, -2.7 -> -2).
+	// This is Go's conversion behavior and the default.
+	RoundTruncate RoundingMode = iota
+
+	// RoundHalfUp rounds to nearest, with halves toward +Inf
+	// (2.5 -> 3, -2.5 -> -2).
+	RoundHalfUp
+
+	// RoundHalfEven rounds to nearest, with halves to the even neighbor
+	// (2.5 -> 2, 3.5 -> 4, -2.5 -> -2). Avoids the upward bias of
+	// RoundHalfUp over many values.
+	RoundHalfEven
+)
+
+// String returns the mode name.
+func (m RoundingMode) String() string {
+	switch m {
+	case RoundTruncate:
+		return "Truncate"
+	case RoundHalfUp:
+		return "HalfUp"
+	case RoundHalfEven:
+		return "HalfEven"
+	default:
+		return fmt.Sprintf("RoundingMode(%d)", int(m))
+	}
+}
+
+// Convert converts x to T using mode. For float T, x is converted
+// unchanged; for integer T, x is rounded first.
+func Convert[T Numeric](x float64, mode RoundingMode) T {
+	if isFloat[T]() {
+		return T(x)
+	}
+
+	switch mode {
+	case RoundHalfUp:
+		x = roundHalfUp(x)
+	case RoundHalfEven:
+		x = math.RoundToEven(x)
+	}
+	return T(x)
+}
+
+// roundHalfUp rounds x to nearest with halves toward +Inf. The fraction
+// is compared directly: math.Floor(x+0.5) is off where the addition itself
+// rounds, e.g. 0.49999999999999994 and large odd values.
+func roundHalfUp(x float64) float64 {
+	t := math.Trunc(x)
+	if x >= 0 {
+		if x-t >= 0.5 {
+			t++
+		}
+	} else if t-x > 0.5 {
+		t--
+	}
+	return t
+}
+
+// isFloat reports whether T is a floating-point type.
+func isFloat[T Numeric]() bool {
+	var half T = 1
+	half /= 2
+	return half != 0
+}
@@ -0,0 +1,49 @@
+package transform
+
+// SampleHold latches an input and repeats it for a number of ticks,
+// modeling a slow sensor polled by a faster clock.
+type SampleHold[T any] struct {
+	holdTicks int
+	held      T
+	remaining int // ticks left before the next sample is accepted
+}
+
+// NewSampleHold creates a transform that samples the input, then outputs
+// that sample for holdTicks ticks in total (the sampling tick included),
+// ignoring the inputs meanwhile. The output therefore changes at most
+// every holdTicks ticks; a holdTicks of 1 passes every input through.
+// Panics if holdTicks is not positive.
+func NewSampleHold[T any](holdTicks int) *SampleHold[T] {
+	if holdTicks <= 0 {
+		panic("sample hold: holdTicks must be positive")
+	}
+	return &SampleHold[T]{holdTicks: holdTicks}
+}
+
+// Apply returns the held sample, latching incoming if the previous hold
+// has ended.
+func (t *SampleHold[T]) Apply(incoming T, state State[T]) T {
+	if t.remaining == 0 {
+		t.held = incoming
+		t.remaining = t.holdTicks
+	}
+	t.remaining--
+	return t.held
+}
+
+// Reset ends the current hold, so the next input is sampled.
+func (t *SampleHold[T]) Reset() {
+	var zero T
+	t.held = zero
+	t.remaining = 0
+}
+
+// Clone returns a new SampleHold with the same hold length and no sample.
+func (t *SampleHold[T]) Clone() Transformation[T] {
+	return NewSampleHold[T](t.holdTicks)
+}
+
+// Name returns the transform identifier.
+func (t *SampleHold[T]) Name() string {
+	return "SampleHold"
+}
@@ -0,0 +1,67 @@
+package transform
+
+import "sync/atomic"
+
+// SaturatingAccumulate adds each value to a running total, clamping at the
+// limits of T instead of wrapping around.
+type SaturatingAccumulate[T Integer] struct {
+	min, max  T
+	saturated atomic.Uint64
+}
+
+// NewSaturatingAccumulate creates a transform that accumulates values like
+// Accumulate, but pins the total at T's maximum or minimum when an addition
+// would overflow. Plain Accumulate wraps instead, so a long-running int
+// total can suddenly turn negative. Each clamped addition is counted in
+// Saturations().
+func NewSaturatingAccumulate[T Integer]() *SaturatingAccumulate[T] {
+	lo, hi := integerLimits[T]()
+	return &SaturatingAccumulate[T]{min: lo, max: hi}
+}
+
+// Apply adds the incoming value to the current state, clamping on overflow.
+func (t *SaturatingAccumulate[T]) Apply(incoming T, state State[T]) T {
+	current := state.GetState()
+	sum := current + incoming
+
+	switch {
+	case incoming > 0 && sum < current:
+		t.saturated.Add(1)
+		return t.max
+	case incoming < 0 && sum > current:
+		t.saturated.Add(1)
+		return t.min
+	}
+	return sum
+}
+
+// Saturations returns the number of additions that were clamped.
+func (t *SaturatingAccumulate[T]) Saturations() uint64 {
+	return t.saturated.Load()
+}
+
+// Clone returns a new SaturatingAccumulate with a zero saturation count.
+func (t *SaturatingAccumulate[T]) Clone() Transformation[T] {
+	return NewSaturatingAccumulate[T]()
+}
+
+// Name returns the transform identifier.
+func (t *SaturatingAccumulate[T]) Name() string {
+	return "SaturatingAccumulate"
+}
+
+// integerLimits returns the minimum and maximum values of T.
+func integerLimits[T Integer]() (lo, hi T) {
+	bits := 0
+	for v := T(1); v != 0; v <<= 1 {
+		bits++
+	}
+
+	var zero T
+	if ^zero > 0 {
+		// Unsigned: all bits set
+		return 0, ^zero
+	}
+	lo = T(1) << (bits - 1)
+	return lo, ^lo
+}
@@ -0,0 +1,41 @@
+package transform
+
+// SubtractLatest subtracts the latest value of a secondary stream from each
+// input, e.g. to remove a baseline.
+type SubtractLatest[T Numeric] struct {
+	other *latest[T]
+}
+
+// NewSubtractLatest creates a transform that returns incoming minus the
+// most recent value published by other. Until other has published, its
+// latest value is treated as zero, so inputs pass through unchanged.
+// other is subscribed to immediately, from a background goroutine.
+// The value running this transform calls Close when it finishes, which
+// unsubscribes from other if it supports Unsubscribe. Otherwise, values
+// from other are discarded until it closes, so it is never blocked on this
+// transform.
+func NewSubtractLatest[T Numeric](other Publisher[T]) *SubtractLatest[T] {
+	return &SubtractLatest[T]{other: newLatest(other)}
+}
+
+// Apply returns the incoming value minus the latest secondary value.
+func (t *SubtractLatest[T]) Apply(incoming T, state State[T]) T {
+	baseline, _ := t.other.get()
+	return incoming - baseline
+}
+
+// Close stops tracking the secondary stream. Safe to call multiple times.
+func (t *SubtractLatest[T]) Close() {
+	t.other.close()
+}
+
+// Clone returns a new SubtractLatest with its own subscription to the same
+// secondary stream.
+func (t *SubtractLatest[T]) Clone() Transformation[T] {
+	return NewSubtractLatest(t.other.other)
+}
+
+// Name returns the transform identifier.
+func (t *SubtractLatest[T]) Name() string {
+	return "SubtractLatest"
+}
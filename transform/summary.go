@@ -0,0 +1,93 @@
+package transform
+
+import "sync"
+
+// Summary tracks the count, minimum, maximum and mean of its inputs
+// together, for dashboards that want all of them from one transform.
+type Summary[T Numeric] struct {
+	mu    sync.Mutex
+	count uint64
+	min   T
+	max   T
+	shift float64 // first input; offsets are measured from it
+	mean  float64 // running mean of input-shift, updated incrementally
+}
+
+// NewSummary creates a transform that records summary statistics of its
+// inputs and returns the input count as the pipeline value.
+// The statistics are available via Min, Max, Mean and Count, which are safe
+// to call from any goroutine while the value is running.
+func NewSummary[T Numeric]() *Summary[T] {
+	return &Summary[T]{}
+}
+
+// Apply records the incoming value and returns the number of inputs seen.
+func (t *Summary[T]) Apply(incoming T, state State[T]) T {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.count++
+	if t.count == 1 || incoming < t.min {
+		t.min = incoming
+	}
+	if t.count == 1 || incoming > t.max {
+		t.max = incoming
+	}
+	// Incremental mean of small offsets avoids the overflow and precision
+	// loss of a running sum over large values
+	if t.count == 1 {
+		t.shift = float64(incoming)
+	}
+	t.mean += (float64(incoming) - t.shift - t.mean) / float64(t.count)
+
+	return T(t.count)
+}
+
+// Min returns the smallest input, or zero before the first input.
+func (t *Summary[T]) Min() T {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.min
+}
+
+// Max returns the largest input, or zero before the first input.
+func (t *Summary[T]) Max() T {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.max
+}
+
+// Mean returns the arithmetic mean of all inputs, or 0 before the first
+// input.
+func (t *Summary[T]) Mean() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.shift + t.mean
+}
+
+// Count returns the number of inputs seen.
+func (t *Summary[T]) Count() uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.count
+}
+
+// Reset forgets all inputs.
+func (t *Summary[T]) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.count = 0
+	t.min, t.max = 0, 0
+	t.shift, t.mean = 0, 0
+}
+
+// Clone returns a new Summary with no inputs.
+func (t *Summary[T]) Clone() Transformation[T] {
+	return NewSummary[T]()
+}
+
+// Name returns the transform identifier.
+func (t *Summary[T]) Name() string {
+	return "Summary"
+}
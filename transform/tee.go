@@ -0,0 +1,45 @@
+package transform
+
+import "sync/atomic"
+
+// Tee passes values through unchanged while copying them to a side channel.
+type Tee[T any] struct {
+	out     chan<- T
+	dropped atomic.Uint64
+}
+
+// NewTee creates a transform that returns its input unchanged and sends a
+// copy to out at that pipeline stage.
+// Sends never block: if out is full (or unbuffered with no receiver ready),
+// the copy is dropped and counted in Dropped(), so the update loop is never
+// stalled. Use a buffered channel to capture bursts.
+func NewTee[T any](out chan<- T) *Tee[T] {
+	return &Tee[T]{out: out}
+}
+
+// Apply sends a copy of the incoming value to the side channel and returns
+// the value unchanged.
+func (t *Tee[T]) Apply(incoming T, state State[T]) T {
+	select {
+	case t.out <- incoming:
+	default:
+		t.dropped.Add(1)
+	}
+	return incoming
+}
+
+// Dropped returns the number of copies dropped because out was full.
+func (t *Tee[T]) Dropped() uint64 {
+	return t.dropped.Load()
+}
+
+// Clone returns a new Tee copying to the same channel, with its own
+// Dropped count.
+func (t *Tee[T]) Clone() Transformation[T] {
+	return NewTee(t.out)
+}
+
+// Name returns the transform identifier.
+func (t *Tee[T]) Name() string {
+	return "Tee"
+}
@@ -0,0 +1,125 @@
+package transform
+
+import (
+	"sync"
+	"time"
+)
+
+// Timestamped carries a value together with the time its source emitted
+// it, so pipeline latency can be measured downstream.
+// Sources wrap values with source.NewTimestampedSource; transforms written
+// for T apply to the payload via NewTimestampedLift, and NewLatencyMeasure
+// at the end of the chain records how far behind the pipeline runs.
+type Timestamped[T any] struct {
+	Value     T
+	EmittedAt time.Time
+}
+
+// TimestampedLift applies a transform to the payload of timestamped values.
+type TimestampedLift[T any] struct {
+	inner Transformation[T]
+}
+
+// NewTimestampedLift creates a transform that applies inner to the Value of
+// each Timestamped input and keeps its EmittedAt, so the emission time
+// survives the whole pipeline. inner sees the Value of the pipeline state
+// as its state.
+func NewTimestampedLift[T any](inner Transformation[T]) *TimestampedLift[T] {
+	return &TimestampedLift[T]{inner: inner}
+}
+
+// Apply returns incoming with inner applied to its Value.
+func (t *TimestampedLift[T]) Apply(incoming Timestamped[T], state State[Timestamped[T]]) Timestamped[T] {
+	incoming.Value = t.inner.Apply(incoming.Value, payloadState[T]{state})
+	return incoming
+}
+
+// Clone returns a new TimestampedLift around a clone of inner (see CloneOf).
+func (t *TimestampedLift[T]) Clone() Transformation[Timestamped[T]] {
+	return NewTimestampedLift(CloneOf(t.inner))
+}
+
+// Name returns the inner transform's name.
+func (t *TimestampedLift[T]) Name() string {
+	return t.inner.Name()
+}
+
+// payloadState exposes the Value of a timestamped pipeline state.
+type payloadState[T any] struct {
+	state State[Timestamped[T]]
+}
+
+func (s payloadState[T]) GetState() T {
+	return s.state.GetState().Value
+}
+
+// LatencyMeasure records the time from emission to the end of the pipeline.
+type LatencyMeasure[T any] struct {
+	mu    sync.Mutex
+	last  time.Duration
+	max   time.Duration
+	total time.Duration
+	count uint64
+}
+
+// NewLatencyMeasure creates a transform that passes timestamped values
+// through unchanged while recording now minus EmittedAt for each.
+// Add it last so the measurement covers every earlier transform.
+// Latencies are available via Last, Max and Mean, which are safe to call
+// from any goroutine while the value is running. Values with a zero
+// EmittedAt are passed through without being measured.
+func NewLatencyMeasure[T any]() *LatencyMeasure[T] {
+	return &LatencyMeasure[T]{}
+}
+
+// Apply records the latency of incoming and returns it unchanged.
+func (t *LatencyMeasure[T]) Apply(incoming Timestamped[T], state State[Timestamped[T]]) Timestamped[T] {
+	if incoming.EmittedAt.IsZero() {
+		return incoming
+	}
+	latency := now().Sub(incoming.EmittedAt)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.last = latency
+	t.max = max(t.max, latency)
+	t.total += latency
+	t.count++
+	return incoming
+}
+
+// Last returns the most recent latency, or 0 before the first value.
+func (t *LatencyMeasure[T]) Last() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.last
+}
+
+// Max returns the largest latency seen.
+func (t *LatencyMeasure[T]) Max() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.max
+}
+
+// Mean returns the average latency, or 0 before the first value.
+func (t *LatencyMeasure[T]) Mean() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.count == 0 {
+		return 0
+	}
+	return t.total / time.Duration(t.count)
+}
+
+// Clone returns a new LatencyMeasure with no measurements.
+func (t *LatencyMeasure[T]) Clone() Transformation[Timestamped[T]] {
+	return NewLatencyMeasure[T]()
+}
+
+// Name returns the transform identifier.
+func (t *LatencyMeasure[T]) Name() string {
+	return "LatencyMeasure"
+}
@@ -11,19 +11,58 @@ type Transformation[T any] interface {
 	Name() string
 }
 
+// Cloner is implemented by transforms that keep internal state between
+// Apply calls. Clone returns a new transform with the same configuration
+// and fresh state, so two values never share (and corrupt) that state.
+type Cloner[T any] interface {
+	Clone() Transformation[T]
+}
+
+// CloneOf returns t.Clone() if t implements Cloner, and t itself
+// otherwise. Transforms without Cloner are assumed stateless and safe to
+// share between values; custom stateful transforms should implement Cloner.
+func CloneOf[T any](t Transformation[T]) Transformation[T] {
+	if c, ok := t.(Cloner[T]); ok {
+		return c.Clone()
+	}
+	return t
+}
+
 // Accumulate adds each value to a running total.
 // Requires T to support the + operator (int, int64, float64, etc.).
-type Accumulate[T Numeric] struct{}
+// Integer totals wrap around on overflow; see OnOverflow and
+// SaturatingAccumulate.
+type Accumulate[T Numeric] struct {
+	onOverflow func()
+}
 
 // NewAccumulate creates a transform that accumulates values.
 func NewAccumulate[T Numeric]() *Accumulate[T] {
 	return &Accumulate[T]{}
 }
 
+// OnOverflow registers fn to be called whenever an addition wraps around.
+// The wrapped total is still returned; fn only observes the overflow.
+// fn runs synchronously in Apply, on the update goroutine, so it should be
+// cheap. Only applies to integer T: float totals never wrap (they become
+// ±Inf), so fn is never called for them.
+// Returns the transform for method chaining.
+func (t *Accumulate[T]) OnOverflow(fn func()) *Accumulate[T] {
+	t.onOverflow = fn
+	return t
+}
+
 // Apply adds the incoming value to the current state and returns the new total.
 func (t *Accumulate[T]) Apply(incoming T, state State[T]) T {
 	current := state.GetState()
-	return current + incoming
+	sum := current + incoming
+
+	// A wrapped sum moves opposite to the sign of incoming
+	if t.onOverflow != nil && !isFloat[T]() &&
+		(incoming > 0 && sum < current || incoming < 0 && sum > current) {
+		t.onOverflow()
+	}
+	return sum
 }
 
 // Name returns the transform identifier.
@@ -37,3 +76,10 @@ type Numeric interface {
 		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
 		~float32 | ~float64
 }
+
+// Integer defines integer types, for transforms that depend on fixed-width
+// integer behavior such as overflow.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
@@ -0,0 +1,855 @@
+package transform_test
+
+import (
+	"math"
+	"math/rand/v2"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neox5/simv/seed"
+	"github.com/neox5/simv/transform"
+)
+
+// ============================================================================
+// HELPERS
+// ============================================================================
+
+// TestMain seeds the global registry once for the random transforms.
+func TestMain(m *testing.M) {
+	seed.Init(1)
+	m.Run()
+}
+
+// state holds pipeline state between applies, like value.Value does.
+type state[T any] struct {
+	current T
+}
+
+func (s *state[T]) GetState() T { return s.current }
+
+// applyAll feeds inputs through t, storing each output as the new state,
+// and returns the outputs.
+func applyAll[T any](t transform.Transformation[T], inputs ...T) []T {
+	s := &state[T]{}
+	out := make([]T, len(inputs))
+	for i, in := range inputs {
+		s.current = t.Apply(in, s)
+		out[i] = s.current
+	}
+	return out
+}
+
+// assertOutputs reports a test error if got differs from want.
+func assertOutputs[T comparable](t *testing.T, got, want []T) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d outputs %v, want %d outputs %v", len(got), got, len(want), want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("output %d: got %v, want %v (got %v, want %v)", i, got[i], want[i], got, want)
+			return
+		}
+	}
+}
+
+// ============================================================================
+// TRANSFORM TESTS
+// ============================================================================
+
+// TestHysteresis_NoChatter verifies noise between the thresholds does not
+// toggle the output.
+func TestHysteresis_NoChatter(t *testing.T) {
+	h := transform.NewHysteresis(18.0, 22.0, 1.0, 0.0)
+
+	got := applyAll[float64](h,
+		19, 21, 20, // between thresholds: stays off
+		23,         // above high: on
+		21, 19, 20, // between thresholds: stays on
+		17, // below low: off
+		21, // between thresholds: stays off
+	)
+	assertOutputs(t, got, []float64{0, 0, 0, 1, 1, 1, 1, 0, 0})
+}
+
+// TestHysteresis_InvalidThresholds verifies low >= high panics.
+func TestHysteresis_InvalidThresholds(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for low >= high")
+		}
+	}()
+	transform.NewHysteresis(5, 5, 1, 0)
+}
+
+// TestDistinctCount_Exact verifies the exact count and Reset.
+func TestDistinctCount_Exact(t *testing.T) {
+	d := transform.NewDistinctCount[int]()
+
+	got := applyAll[int](d, 3, 1, 3, 2, 1, 5)
+	assertOutputs(t, got, []int{1, 2, 2, 3, 3, 4})
+
+	d.Reset()
+	assertOutputs(t, applyAll[int](d, 7, 7), []int{1, 1})
+}
+
+// TestDistinctCount_HLL verifies the estimate is close to the true
+// cardinality for a large input.
+func TestDistinctCount_HLL(t *testing.T) {
+	const distinct = 100000
+	d := transform.NewDistinctCountHLL[int](14)
+
+	inputs := make([]int, 0, 2*distinct)
+	for i := range distinct {
+		inputs = append(inputs, i, i) // every value twice
+	}
+	out := applyAll[int](d, inputs...)
+
+	got := out[len(out)-1]
+	if errPct := math.Abs(float64(got-distinct)) / distinct * 100; errPct > 3 {
+		t.Errorf("estimate %d is %.2f%% off true cardinality %d", got, errPct, distinct)
+	}
+}
+
+// TestDistinctCount_HLLRounding verifies integer estimates are converted
+// from the float estimate with the configured rounding mode.
+func TestDistinctCount_HLLRounding(t *testing.T) {
+	inputs := make([]int, 200)
+	for i := range inputs {
+		inputs[i] = i
+	}
+	floats := make([]float64, len(inputs))
+	for i, in := range inputs {
+		floats[i] = float64(in)
+	}
+	estimates := applyAll[float64](transform.NewDistinctCountHLL[float64](4), floats...)
+
+	truncated := applyAll[int](transform.NewDistinctCountHLL[int](4), inputs...)
+	halfUp := applyAll[int](transform.NewDistinctCountHLL[int](4).WithRounding(transform.RoundHalfUp), inputs...)
+	fractional := 0
+	for i, est := range estimates {
+		if truncated[i] != int(est) || halfUp[i] != int(math.Floor(est+0.5)) {
+			t.Fatalf("input %d: estimate %v gave truncated %d and half-up %d", i, est, truncated[i], halfUp[i])
+		}
+		if est != math.Trunc(est) {
+			fractional++
+		}
+	}
+	if fractional == 0 {
+		t.Error("no fractional estimates: float results should not be rounded")
+	}
+}
+
+// TestUnitConversion verifies registry conversions for float and integer types.
+func TestUnitConversion(t *testing.T) {
+	ms := transform.NewUnitConversion[float64]("ms->s")
+	assertOutputs(t, applyAll[float64](ms, 1500, 250), []float64{1.5, 0.25})
+
+	// Integer results truncate toward zero
+	mb := transform.NewUnitConversion[int]("B->MB")
+	assertOutputs(t, applyAll[int](mb, 2500000, 999999, -1500000), []int{2, 0, -1})
+
+	if got, want := mb.Name(), "UnitScale(B->MB)"; got != want {
+		t.Errorf("Name() = %q, want %q", got, want)
+	}
+}
+
+// TestUnitConversion_RoundTrip verifies every conversion has a consistent
+// inverse where both directions are registered.
+func TestUnitConversion_RoundTrip(t *testing.T) {
+	registered := make(map[string]bool)
+	for _, name := range transform.UnitConversions() {
+		registered[name] = true
+	}
+
+	for name := range registered {
+		from, to, _ := strings.Cut(name, "->")
+		inverse := to + "->" + from
+		if !registered[inverse] {
+			continue
+		}
+		there := transform.NewUnitConversion[float64](name)
+		back := transform.NewUnitConversion[float64](inverse)
+
+		got := back.Apply(there.Apply(123, nil), nil)
+		if math.Abs(got-123) > 1e-9 {
+			t.Errorf("%s then %s: got %v, want 123", name, inverse, got)
+		}
+	}
+}
+
+// TestUnitScale_Invalid verifies invalid factors and unknown names panic.
+func TestUnitScale_Invalid(t *testing.T) {
+	for name, fn := range map[string]func(){
+		"zero factor":     func() { transform.NewUnitScale[int](0, "zero") },
+		"NaN factor":      func() { transform.NewUnitScale[int](math.NaN(), "nan") },
+		"unknown convert": func() { transform.NewUnitConversion[int]("furlongs->m") },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			fn()
+		})
+	}
+}
+
+// TestResetWhen_After verifies the reset replaces the triggering state.
+func TestResetWhen_After(t *testing.T) {
+	pipeline := transform.NewChain[int](
+		transform.NewAccumulate[int](),
+		transform.NewResetWhen(func(s int) bool { return s >= 10 }, 0),
+	)
+	got := applyAll[int](pipeline, 4, 4, 4, 4, 4, 4)
+	assertOutputs(t, got, []int{4, 8, 0, 4, 8, 0})
+}
+
+// TestResetWhen_Before verifies the triggering state is observable for one
+// update and the next input starts a new batch.
+func TestResetWhen_Before(t *testing.T) {
+	pipeline := transform.NewChain[int](
+		transform.NewAccumulate[int](),
+		transform.NewResetWhenBefore(func(s int) bool { return s >= 10 }, 0),
+	)
+	got := applyAll[int](pipeline, 4, 4, 4, 4, 4, 4)
+	assertOutputs(t, got, []int{4, 8, 12, 4, 8, 12})
+}
+
+// TestBatchSum_Boundary verifies the batch total is emitted for exactly one
+// update at each boundary before the sum restarts.
+func TestBatchSum_Boundary(t *testing.T) {
+	b := transform.NewBatchSum[int](3)
+
+	got := applyAll[int](b, 1, 1, 1, 1, 1, 1, 1)
+	assertOutputs(t, got, []int{1, 2, 3, 1, 2, 3, 1})
+
+	b.Reset()
+	assertOutputs(t, applyAll[int](b, 5, 5), []int{5, 10})
+}
+
+// TestConvert_RoundingModes verifies each rounding mode on halves and
+// negative values, and that float types are never rounded.
+func TestConvert_RoundingModes(t *testing.T) {
+	inputs := []float64{2.5, 3.5, -2.5, 2.7, -2.7}
+	want := map[transform.RoundingMode][]int{
+		transform.RoundTruncate: {2, 3, -2, 2, -2},
+		transform.RoundHalfUp:   {3, 4, -2, 3, -3},
+		transform.RoundHalfEven: {2, 4, -2, 3, -3},
+	}
+
+	for mode, expected := range want {
+		t.Run(mode.String(), func(t *testing.T) {
+			got := make([]int, len(inputs))
+			for i, x := range inputs {
+				got[i] = transform.Convert[int](x, mode)
+			}
+			assertOutputs(t, got, expected)
+
+			if f := transform.Convert[float64](2.5, mode); f != 2.5 {
+				t.Errorf("float64: got %v, want 2.5 unchanged", f)
+			}
+		})
+	}
+}
+
+// TestConvert_HalfUpEdges verifies RoundHalfUp where adding 0.5 would
+// itself round: just below a half, and odd values beyond 2^52.
+func TestConvert_HalfUpEdges(t *testing.T) {
+	for _, tc := range []struct {
+		x    float64
+		want int64
+	}{
+		{0.49999999999999994, 0},
+		{-0.49999999999999994, 0},
+		{-0.5, 0},
+		{-1.5, -1},
+		{-2.5000000000000004, -3},
+		{1<<53 - 1, 1<<53 - 1},
+		{-(1<<53 - 1), -(1<<53 - 1)},
+		{1<<52 + 1, 1<<52 + 1},
+	} {
+		if got := transform.Convert[int64](tc.x, transform.RoundHalfUp); got != tc.want {
+			t.Errorf("Convert(%v): got %d, want %d", tc.x, got, tc.want)
+		}
+	}
+
+	if got := transform.RoundingMode(7).String(); got != "RoundingMode(7)" {
+		t.Errorf("unknown mode: got %q, want RoundingMode(7)", got)
+	}
+}
+
+// TestUnitScale_WithRounding verifies rounding applies to unit conversions.
+func TestUnitScale_WithRounding(t *testing.T) {
+	mb := transform.NewUnitConversion[int]("B->MB").WithRounding(transform.RoundHalfEven)
+	assertOutputs(t, applyAll[int](mb, 2500000, 3500000, 999999), []int{2, 4, 1})
+}
+
+// TestSaturatingAccumulate_NearMax verifies the total clamps at the type
+// limits instead of wrapping.
+func TestSaturatingAccumulate_NearMax(t *testing.T) {
+	a := transform.NewSaturatingAccumulate[int64]()
+	s := &state[int64]{current: math.MaxInt64 - 1}
+
+	steps := []struct{ in, want int64 }{
+		{1, math.MaxInt64},      // reaches max exactly
+		{1, math.MaxInt64},      // would wrap: clamped
+		{-5, math.MaxInt64 - 5}, // leaves saturation normally
+	}
+	for _, step := range steps {
+		s.current = a.Apply(step.in, s)
+		if s.current != step.want {
+			t.Fatalf("apply %d: got %d, want %d", step.in, s.current, step.want)
+		}
+	}
+	if got := a.Saturations(); got != 1 {
+		t.Errorf("saturations: got %d, want 1", got)
+	}
+
+	s.current = math.MinInt64 + 1
+	if got := a.Apply(-3, s); got != math.MinInt64 {
+		t.Errorf("below min: got %d, want %d", got, int64(math.MinInt64))
+	}
+
+	u := transform.NewSaturatingAccumulate[uint8]()
+	assertOutputs(t, applyAll[uint8](u, 200, 100, 1), []uint8{200, 255, 255})
+}
+
+// chanPublisher publishes values sent on its channel.
+type chanPublisher[T any] chan T
+
+func (p chanPublisher[T]) Subscribe() <-chan T { return p }
+
+// TestSubtractLatest_Baseline verifies inputs pass through until the
+// secondary stream publishes, then have its latest value subtracted.
+func TestSubtractLatest_Baseline(t *testing.T) {
+	baseline := make(chanPublisher[int])
+	sub := transform.NewSubtractLatest[int](baseline)
+	defer close(baseline)
+
+	if got := sub.Apply(15, &state[int]{}); got != 15 {
+		t.Errorf("before baseline: got %d, want 15", got)
+	}
+
+	// The second send is only received once the first has been recorded
+	baseline <- 10
+	baseline <- 10
+	if got := sub.Apply(15, &state[int]{}); got != 5 {
+		t.Errorf("with baseline 10: got %d, want 5", got)
+	}
+
+	sub.Close()
+	baseline <- 100 // discarded, not blocked
+}
+
+// TestAccumulate_OnOverflow verifies the hook fires on wrap-around while
+// the wrapped total is still returned.
+func TestAccumulate_OnOverflow(t *testing.T) {
+	overflows := 0
+	a := transform.NewAccumulate[int8]().OnOverflow(func() { overflows++ })
+
+	assertOutputs(t, applyAll[int8](a, 100, 27, 1, -1), []int8{100, 127, -128, 127})
+	if overflows != 2 {
+		t.Errorf("overflows: got %d, want 2", overflows)
+	}
+}
+
+// vec2 is a 2D vector for struct accumulation tests.
+type vec2 struct{ X, Y float64 }
+
+func addVec2(a, b vec2) vec2 { return vec2{a.X + b.X, a.Y + b.Y} }
+
+// TestFieldAccumulate_Vector verifies a struct total accumulates per field.
+func TestFieldAccumulate_Vector(t *testing.T) {
+	pos := transform.NewFieldAccumulate(addVec2)
+
+	got := applyAll[vec2](pos, vec2{1, 0}, vec2{0, 2}, vec2{-0.5, 1})
+	assertOutputs(t, got, []vec2{{1, 0}, {1, 2}, {0.5, 3}})
+}
+
+// TestDropout_Extremes verifies probability 0 never drops and 1 always does.
+func TestDropout_Extremes(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	never := transform.NewDropoutWithRand(0, -1, rng)
+	assertOutputs(t, applyAll[int](never, 1, 2, 3), []int{1, 2, 3})
+
+	always := transform.NewDropoutWithRand(1, -1, rng)
+	assertOutputs(t, applyAll[int](always, 1, 2, 3), []int{-1, -1, -1})
+}
+
+// TestCDF_Gaussian verifies the fractions of standard normal inputs match
+// the theoretical CDF.
+func TestCDF_Gaussian(t *testing.T) {
+	thresholds := []float64{-2, -1, 0, 1, 2}
+	cdf := transform.NewCDF(thresholds)
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	var last float64
+	for range 50000 {
+		last = cdf.Apply(rng.NormFloat64(), &state[float64]{})
+	}
+
+	fractions := cdf.Fractions()
+	for i, x := range thresholds {
+		want := 0.5 * math.Erfc(-x/math.Sqrt2)
+		if math.Abs(fractions[i]-want) > 0.01 {
+			t.Errorf("P(X <= %v): got %.4f, want %.4f", x, fractions[i], want)
+		}
+	}
+	if last != fractions[2] {
+		t.Errorf("Apply returned %v, want median threshold fraction %v", last, fractions[2])
+	}
+}
+
+// TestWindowedSum_Eviction verifies inputs leave the window as time passes,
+// including on zero inputs.
+func TestWindowedSum_Eviction(t *testing.T) {
+	at := time.Unix(0, 0)
+	transform.SetNowFunc(func() time.Time { return at })
+	t.Cleanup(func() { transform.SetNowFunc(nil) })
+
+	w := transform.NewWindowedSum[int](time.Minute)
+	s := &state[int]{}
+	steps := []struct {
+		advance time.Duration
+		in      int
+		want    int
+	}{
+		{0, 5, 5},
+		{30 * time.Second, 3, 8},
+		{30 * time.Second, 0, 3}, // first input is now a minute old
+		{30 * time.Second, 0, 0}, // second input too
+		{10 * time.Second, 2, 2},
+	}
+	for i, step := range steps {
+		at = at.Add(step.advance)
+		if got := w.Apply(step.in, s); got != step.want {
+			t.Errorf("step %d: got %d, want %d", i, got, step.want)
+		}
+	}
+}
+
+// TestWindowedSum_ResetAndClone verifies the sum covers recent inputs,
+// decays in real time, and Reset and Clone start with an empty window.
+func TestWindowedSum_ResetAndClone(t *testing.T) {
+	w := transform.NewWindowedSum[int](50 * time.Millisecond)
+	s := &state[int]{}
+
+	w.Apply(5, s)
+	if got := w.Apply(3, s); got != 8 {
+		t.Errorf("within window: got %d, want 8", got)
+	}
+	if got := w.Clone().Apply(1, s); got != 1 {
+		t.Errorf("clone: got %d, want 1 from an empty window", got)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if got := w.Apply(0, s); got != 0 {
+		t.Errorf("after the window passed: got %d, want 0", got)
+	}
+
+	w.Apply(4, s)
+	w.Reset()
+	if got := w.Apply(2, s); got != 2 {
+		t.Errorf("after Reset: got %d, want 2", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("zero window: expected panic")
+		}
+	}()
+	transform.NewWindowedSum[int](0)
+}
+
+// TestFilter_HoldsLastKept verifies dropped runs hold the last kept value.
+func TestFilter_HoldsLastKept(t *testing.T) {
+	positive := transform.NewFilter("positive", func(x int) bool { return x > 0 })
+
+	got := applyAll[int](positive,
+		-1,     // dropped before any kept value: zero state
+		3,      // kept
+		-2, -5, // dropped run: hold 3
+		4,     // kept
+		0, -1, // dropped run: hold 4
+	)
+	assertOutputs(t, got, []int{0, 3, 3, 3, 4, 4, 4})
+
+	if got := positive.Name(); got != "Filter(positive)" {
+		t.Errorf("Name: got %q, want %q", got, "Filter(positive)")
+	}
+}
+
+// TestSummary_KnownSequence verifies summary statistics and Reset.
+func TestSummary_KnownSequence(t *testing.T) {
+	sum := transform.NewSummary[int]()
+
+	got := applyAll[int](sum, 4, -2, 9, 1)
+	assertOutputs(t, got, []int{1, 2, 3, 4})
+
+	if sum.Min() != -2 || sum.Max() != 9 || sum.Count() != 4 || sum.Mean() != 3 {
+		t.Errorf("got min=%d max=%d count=%d mean=%v, want -2 9 4 3",
+			sum.Min(), sum.Max(), sum.Count(), sum.Mean())
+	}
+
+	sum.Reset()
+	if sum.Min() != 0 || sum.Max() != 0 || sum.Count() != 0 || sum.Mean() != 0 {
+		t.Errorf("after Reset: got min=%d max=%d count=%d mean=%v, want zeros",
+			sum.Min(), sum.Max(), sum.Count(), sum.Mean())
+	}
+	if got := applyAll[int](sum, 7); got[0] != 1 || sum.Min() != 7 || sum.Max() != 7 {
+		t.Errorf("after Reset: got count=%d min=%d max=%d, want 1 7 7", got[0], sum.Min(), sum.Max())
+	}
+}
+
+// TestSummary_StableMean verifies the mean stays accurate for large values
+// with a small spread.
+func TestSummary_StableMean(t *testing.T) {
+	sum := transform.NewSummary[float64]()
+
+	s := &state[float64]{}
+	for i := range 999_999 {
+		sum.Apply(1e9+float64(i%3), s) // 1e9, 1e9+1, 1e9+2, ...
+	}
+	if got, want := sum.Mean(), 1e9+1; math.Abs(got-want) > 1e-6 {
+		t.Errorf("Mean: got %.9f, want %.9f", got, want)
+	}
+}
+
+// TestLatencyMeasure_LiftedPipeline verifies emission times survive lifted
+// transforms and latencies are measured at the end of the chain.
+func TestLatencyMeasure_LiftedPipeline(t *testing.T) {
+	at := time.Unix(100, 0)
+	transform.SetNowFunc(func() time.Time { return at })
+	t.Cleanup(func() { transform.SetNowFunc(nil) })
+
+	latency := transform.NewLatencyMeasure[int]()
+	chain := transform.NewChain[transform.Timestamped[int]](
+		transform.NewTimestampedLift[int](transform.NewAccumulate[int]()),
+		latency,
+	)
+
+	emitted := func(v int, ago time.Duration) transform.Timestamped[int] {
+		return transform.Timestamped[int]{Value: v, EmittedAt: at.Add(-ago)}
+	}
+	got := applyAll[transform.Timestamped[int]](chain,
+		emitted(2, 10*time.Millisecond),
+		emitted(3, 30*time.Millisecond),
+		emitted(5, 20*time.Millisecond),
+	)
+
+	if last := got[len(got)-1]; last.Value != 10 || !last.EmittedAt.Equal(at.Add(-20*time.Millisecond)) {
+		t.Errorf("output: got %+v, want value 10 emitted 20ms ago", last)
+	}
+	if latency.Last() != 20*time.Millisecond || latency.Max() != 30*time.Millisecond || latency.Mean() != 20*time.Millisecond {
+		t.Errorf("got last=%v max=%v mean=%v, want 20ms 30ms 20ms",
+			latency.Last(), latency.Max(), latency.Mean())
+	}
+	if got := chain.Name(); got != "Accumulate+LatencyMeasure" {
+		t.Errorf("Name: got %q, want %q", got, "Accumulate+LatencyMeasure")
+	}
+}
+
+// TestPID_StepResponse verifies a PID driving a first-order plant settles
+// at the setpoint without diverging.
+func TestPID_StepResponse(t *testing.T) {
+	const step = 10 * time.Millisecond
+	pid := transform.NewPID(2, 1, 0.05, 1).WithTimeStep(step)
+
+	// Plant: dx/dt = (u - x) / tau
+	const tau = 0.5
+	s := &state[float64]{}
+	x, peak := 0.0, 0.0
+	for range 2000 { // 20s of simulated time
+		u := pid.Apply(x, s)
+		x += step.Seconds() * (u - x) / tau
+		peak = max(peak, x)
+	}
+
+	if math.Abs(x-1) > 1e-3 {
+		t.Errorf("settled at %v, want setpoint 1", x)
+	}
+	if peak > 1.5 {
+		t.Errorf("peak %v, want overshoot below 50%%", peak)
+	}
+}
+
+// TestPID_InvalidGains verifies negative and non-finite gains panic.
+func TestPID_InvalidGains(t *testing.T) {
+	for name, fn := range map[string]func(){
+		"negative kp": func() { transform.NewPID(-1, 0, 0, 0) },
+		"NaN ki":      func() { transform.NewPID(1, math.NaN(), 0, 0) },
+		"infinite kd": func() { transform.NewPID(1, 0, math.Inf(1), 0) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			fn()
+		})
+	}
+}
+
+// TestAccumulateProduct_Compounding verifies the product starts from the
+// identity, compounds, and restarts after Reset.
+func TestAccumulateProduct_Compounding(t *testing.T) {
+	p := transform.NewAccumulateProduct[float64]()
+	s := &state[float64]{}
+
+	for _, m := range []float64{1.1, 1.1, 1.1} {
+		s.current = p.Apply(m, s)
+	}
+	if math.Abs(s.current-1.331) > 1e-12 {
+		t.Errorf("got %v, want 1.331", s.current)
+	}
+
+	p.Reset()
+	if got := p.Apply(2, s); got != 2 {
+		t.Errorf("after Reset: got %v, want 2", got)
+	}
+}
+
+// TestAccumulateProduct_OnOverflow verifies wrapped integer products are
+// reported, including minInt * -1.
+func TestAccumulateProduct_OnOverflow(t *testing.T) {
+	overflows := 0
+	p := transform.NewAccumulateProduct[int8]().OnOverflow(func() { overflows++ })
+
+	got := applyAll[int8](p, 16, 4, 2, -1) // 16, 64, 128 wraps to -128, -128 * -1 wraps
+	assertOutputs(t, got, []int8{16, 64, -128, -128})
+	if overflows != 2 {
+		t.Errorf("overflows: got %d, want 2", overflows)
+	}
+}
+
+// TestAccumulateProduct_Baseline verifies a nonzero state before the first
+// Apply is compounded, and Reset ignores it.
+func TestAccumulateProduct_Baseline(t *testing.T) {
+	p := transform.NewAccumulateProduct[float64]()
+	s := &state[float64]{current: 100}
+
+	s.current = p.Apply(1.5, s)
+	s.current = p.Apply(2, s)
+	if s.current != 300 {
+		t.Errorf("got %v, want 300", s.current)
+	}
+
+	p.Reset()
+	if got := p.Apply(3, s); got != 3 {
+		t.Errorf("after Reset: got %v, want 3", got)
+	}
+	if got := p.Clone().Apply(3, s); got != 900 {
+		t.Errorf("clone: got %v, want 900 compounding the state", got)
+	}
+}
+
+// TestAccumulateProduct_MinIntOverflow verifies math.MinInt64 * -1 is
+// reported: its quotient check alone would miss it.
+func TestAccumulateProduct_MinIntOverflow(t *testing.T) {
+	overflows := 0
+	p := transform.NewAccumulateProduct[int64]().OnOverflow(func() { overflows++ })
+
+	got := applyAll[int64](p, math.MinInt64, -1, 1)
+	assertOutputs(t, got, []int64{math.MinInt64, math.MinInt64, math.MinInt64})
+	if overflows != 1 {
+		t.Errorf("overflows: got %d, want 1", overflows)
+	}
+}
+
+// TestCorrelation_Ramps verifies linearly related ramps correlate fully,
+// and inputs before the secondary stream publishes are not paired.
+func TestCorrelation_Ramps(t *testing.T) {
+	for name, slope := range map[string]float64{"positive": 2, "negative": -3} {
+		t.Run(name, func(t *testing.T) {
+			other := make(chanPublisher[float64])
+			corr := transform.NewCorrelation(other)
+			defer close(other)
+			defer corr.Close()
+
+			s := &state[float64]{}
+			if got := corr.Apply(1, s); got != 0 {
+				t.Errorf("before other published: got %v, want 0", got)
+			}
+
+			var got float64
+			for i := range 100 {
+				x := float64(i)
+				// The second send is only received once the first has been recorded
+				other <- slope*x + 1
+				other <- slope*x + 1
+				got = corr.Apply(x, s)
+			}
+			if want := math.Copysign(1, slope); math.Abs(got-want) > 1e-9 {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+// TestBackoff_Transitions verifies doubling up to the cap and reset on
+// success.
+func TestBackoff_Transitions(t *testing.T) {
+	const ms = time.Millisecond
+	b := transform.NewBackoff(100*ms, 500*ms)
+
+	got := applyAll[time.Duration](b,
+		0,          // success: no delay
+		1, 1, 1, 1, // failures: 100, 200, 400, capped at 500
+		1, // stays at the cap
+		0, // success resets
+		7, // any nonzero input is a failure
+	)
+	assertOutputs(t, got, []time.Duration{0, 100 * ms, 200 * ms, 400 * ms, 500 * ms, 500 * ms, 0, 100 * ms})
+}
+
+// TestSampleHold_ChangesEveryHold verifies a varying input is resampled
+// exactly every holdTicks ticks, and Reset samples the next input.
+func TestSampleHold_ChangesEveryHold(t *testing.T) {
+	h := transform.NewSampleHold[int](3)
+
+	got := applyAll[int](h, 1, 2, 3, 4, 5, 6, 7)
+	assertOutputs(t, got, []int{1, 1, 1, 4, 4, 4, 7})
+
+	h.Reset()
+	got = applyAll[int](h, 8, 9)
+	assertOutputs(t, got, []int{8, 8})
+}
+
+// TestCounterDelta_Reset verifies a counter dropping back to zero mid-run
+// yields its new value as the increase instead of a negative delta.
+func TestCounterDelta_Reset(t *testing.T) {
+	d := transform.NewCounterDelta[int]()
+
+	got := applyAll[int](d,
+		10, 13, 20, // ramp
+		0, 4, // reset to zero, then counting again
+		2, // reset without observing zero
+	)
+	assertOutputs(t, got, []int{0, 3, 7, 0, 4, 2})
+}
+
+// TestMarkov_Transitions verifies the incoming value seeds the state,
+// certain transitions are always taken, and splits follow their
+// probabilities.
+func TestMarkov_Transitions(t *testing.T) {
+	m := transform.NewMarkov(map[string]map[string]float64{
+		"idle": {"busy": 1},
+		"busy": {"idle": 0.25, "busy": 0.75},
+	})
+	s := &state[string]{}
+
+	if s.current = m.Apply("idle", s); s.current != "idle" {
+		t.Fatalf("seed: got %q, want the incoming idle", s.current)
+	}
+	if s.current = m.Apply("", s); s.current != "busy" {
+		t.Fatalf("from idle: got %q, want busy", s.current)
+	}
+
+	const n = 20000
+	idle := 0
+	for range n {
+		s.current = "busy"
+		if m.Apply("", s) == "idle" {
+			idle++
+		}
+	}
+	if frac := float64(idle) / n; math.Abs(frac-0.25) > 0.02 {
+		t.Errorf("busy -> idle: got fraction %.3f, want 0.25", frac)
+	}
+}
+
+// TestMarkov_RowSums verifies rows must sum to 1 within tolerance and
+// hold no negative probability.
+func TestMarkov_RowSums(t *testing.T) {
+	transform.NewMarkov(map[int]map[int]float64{0: {0: 1.0 / 3, 1: 2.0 / 3}}) // rounding tolerated
+
+	for name, matrix := range map[string]map[int]map[int]float64{
+		"below 1":  {0: {0: 0.5, 1: 0.4}},
+		"above 1":  {0: {0: 0.5, 1: 0.6}},
+		"negative": {0: {0: 1.5, 1: -0.5}},
+		"empty":    {0: {}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			transform.NewMarkov(matrix)
+		})
+	}
+}
+
+// TestTee_DropsWhenFull verifies values pass through unchanged, copies
+// fill the side channel, and a full or unread channel never blocks Apply.
+func TestTee_DropsWhenFull(t *testing.T) {
+	side := make(chan int, 2)
+	tee := transform.NewTee[int](side)
+
+	assertOutputs(t, applyAll[int](tee, 1, 2, 3, 4), []int{1, 2, 3, 4})
+	if got := []int{<-side, <-side}; got[0] != 1 || got[1] != 2 {
+		t.Errorf("side channel: got %v, want the first two copies", got)
+	}
+	if got := tee.Dropped(); got != 2 {
+		t.Errorf("Dropped: got %d, want 2", got)
+	}
+
+	slow := transform.NewTee[int](make(chan int)) // no receiver ready
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		applyAll[int](slow, 1, 2, 3)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Apply blocked on an unread side channel")
+	}
+	if got := slow.Dropped(); got != 3 {
+		t.Errorf("unread channel: got %d dropped, want 3", got)
+	}
+}
+
+// TestZScoreFlag_WarmupAndOutliers verifies nothing is flagged during the
+// 10-input warm-up, then outliers are flagged and typical inputs are not.
+func TestZScoreFlag_WarmupAndOutliers(t *testing.T) {
+	z := transform.NewZScoreFlag(3)
+	s := &state[float64]{}
+
+	// Alternating 9 and 11: mean 10, standard deviation about 1
+	for i := range 9 {
+		if got := z.Apply(float64(9+2*(i%2)), s); got != 0 {
+			t.Fatalf("warm-up input %d: got %v, want 0", i, got)
+		}
+	}
+	if got := z.Apply(100, s); got != 0 {
+		t.Errorf("10th input: got %v, want 0 during warm-up", got)
+	}
+
+	fresh := z.Clone()
+	for range 10 {
+		fresh.Apply(10, s)
+	}
+	if got := fresh.Apply(10, s); got != 0 {
+		t.Errorf("constant input: got %v, want 0", got)
+	}
+	if got := fresh.Apply(10.5, s); got != 1 {
+		t.Errorf("after constant inputs: got %v, want 1 for any change", got)
+	}
+
+	spread := z.Clone()
+	for i := range 20 {
+		spread.Apply(float64(9+2*(i%2)), s)
+	}
+	if got := spread.Apply(11.5, s); got != 0 {
+		t.Errorf("typical input: got %v, want 0", got)
+	}
+	if got := spread.Apply(20, s); got != 1 {
+		t.Errorf("outlier: got %v, want 1", got)
+	}
+}
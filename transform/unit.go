@@ -0,0 +1,114 @@
+package transform
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// unitFactors maps named conversions to their scale factors.
+var unitFactors = map[string]float64{
+	// Data size (decimal)
+	"B->KB":  1e-3,
+	"B->MB":  1e-6,
+	"B->GB":  1e-9,
+	"KB->B":  1e3,
+	"MB->B":  1e6,
+	"GB->B":  1e9,
+	"KB->MB": 1e-3,
+	"MB->GB": 1e-3,
+
+	// Data size (binary)
+	"B->KiB":   1.0 / (1 << 10),
+	"B->MiB":   1.0 / (1 << 20),
+	"B->GiB":   1.0 / (1 << 30),
+	"KiB->B":   1 << 10,
+	"MiB->B":   1 << 20,
+	"GiB->B":   1 << 30,
+	"bits->B":  1.0 / 8,
+	"B->bits":  8,
+	"KiB->MiB": 1.0 / (1 << 10),
+
+	// Time
+	"ns->us":  1e-3,
+	"ns->ms":  1e-6,
+	"ns->s":   1e-9,
+	"us->ms":  1e-3,
+	"us->s":   1e-6,
+	"ms->s":   1e-3,
+	"s->ms":   1e3,
+	"s->us":   1e6,
+	"s->ns":   1e9,
+	"ms->us":  1e3,
+	"ms->ns":  1e6,
+	"s->min":  1.0 / 60,
+	"min->s":  60,
+	"s->h":    1.0 / 3600,
+	"h->s":    3600,
+	"min->h":  1.0 / 60,
+	"h->min":  60,
+	"ms->min": 1.0 / 60e3,
+}
+
+// UnitScale multiplies each value by a fixed conversion factor.
+type UnitScale[T Numeric] struct {
+	factor   float64
+	name     string
+	rounding RoundingMode
+}
+
+// NewUnitScale creates a transform that multiplies each value by factor.
+// name describes the conversion (e.g. "B->MB") and appears in traces.
+// The product is computed in float64; for integer T it is truncated toward
+// zero by default, so small results may become 0 (e.g. 999 bytes is 0 MB).
+// Use WithRounding to choose a different RoundingMode.
+// Panics if factor is zero, NaN or infinite.
+func NewUnitScale[T Numeric](factor float64, name string) *UnitScale[T] {
+	if factor == 0 || math.IsNaN(factor) || math.IsInf(factor, 0) {
+		panic(fmt.Sprintf("unit scale: invalid factor %v", factor))
+	}
+	return &UnitScale[T]{
+		factor: factor,
+		name:   name,
+	}
+}
+
+// WithRounding sets how results are converted to integer T.
+// Has no effect for float T.
+// Returns the transform for method chaining.
+func (t *UnitScale[T]) WithRounding(mode RoundingMode) *UnitScale[T] {
+	t.rounding = mode
+	return t
+}
+
+// NewUnitConversion creates a UnitScale for a named conversion from the
+// built-in registry, such as "B->MB", "ms->s" or "B->MiB".
+// See UnitConversions for all available names.
+// Panics if the conversion is unknown.
+func NewUnitConversion[T Numeric](conversion string) *UnitScale[T] {
+	factor, ok := unitFactors[conversion]
+	if !ok {
+		panic(fmt.Sprintf("unit scale: unknown conversion %q", conversion))
+	}
+	return NewUnitScale[T](factor, conversion)
+}
+
+// UnitConversions returns the names of all built-in conversions, sorted.
+func UnitConversions() []string {
+	names := make([]string, 0, len(unitFactors))
+	for name := range unitFactors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Apply returns the incoming value multiplied by the factor.
+func (t *UnitScale[T]) Apply(incoming T, state State[T]) T {
+	return Convert[T](float64(incoming)*t.factor, t.rounding)
+}
+
+// Name returns the transform identifier including the conversion.
+func (t *UnitScale[T]) Name() string {
+	return "UnitScale(" + t.name + ")"
+}
@@ -0,0 +1,75 @@
+package transform
+
+import "time"
+
+// WindowedSum sums the inputs received within a trailing time window.
+type WindowedSum[T Numeric] struct {
+	window  time.Duration
+	samples []windowSample[T] // oldest first
+	sum     T
+}
+
+// windowSample is one timestamped input.
+type windowSample[T Numeric] struct {
+	at    time.Time
+	value T
+}
+
+// NewWindowedSum creates a transform that returns the sum of all inputs
+// received in the trailing window, e.g. "requests in the last minute".
+// Inputs are timestamped on arrival (see SetNowFunc), so the result does
+// not depend on the tick rate. Inputs older than window are evicted on every Apply,
+// including ones whose input is zero, so the sum decays as time passes.
+// Memory grows with the number of inputs per window.
+// Panics if window is not positive.
+func NewWindowedSum[T Numeric](window time.Duration) *WindowedSum[T] {
+	if window <= 0 {
+		panic("windowed sum: window must be positive")
+	}
+	return &WindowedSum[T]{window: window}
+}
+
+// Apply records the incoming value and returns the sum over the window.
+func (t *WindowedSum[T]) Apply(incoming T, state State[T]) T {
+	at := now()
+	t.evict(at)
+
+	t.samples = append(t.samples, windowSample[T]{at: at, value: incoming})
+	t.sum += incoming
+	return t.sum
+}
+
+// evict drops samples that are older than the window at now.
+func (t *WindowedSum[T]) evict(now time.Time) {
+	cutoff := now.Add(-t.window)
+	n := 0
+	for n < len(t.samples) && !t.samples[n].at.After(cutoff) {
+		t.sum -= t.samples[n].value
+		n++
+	}
+	if n == 0 {
+		return
+	}
+	// Reslicing is O(1); append reallocates and drops the evicted prefix
+	t.samples = t.samples[n:]
+	if len(t.samples) == 0 {
+		// Reset exactly, so float rounding errors do not accumulate
+		t.sum = 0
+	}
+}
+
+// Reset forgets all samples.
+func (t *WindowedSum[T]) Reset() {
+	t.samples = nil
+	t.sum = 0
+}
+
+// Clone returns a new WindowedSum with the same window and no samples.
+func (t *WindowedSum[T]) Clone() Transformation[T] {
+	return NewWindowedSum[T](t.window)
+}
+
+// Name returns the transform identifier.
+func (t *WindowedSum[T]) Name() string {
+	return "WindowedSum"
+}
@@ -0,0 +1,56 @@
+package transform
+
+import "math"
+
+// zScoreWarmup is the number of samples ZScoreFlag observes before flagging.
+const zScoreWarmup = 10
+
+// ZScoreFlag flags inputs that deviate strongly from the running mean.
+type ZScoreFlag struct {
+	threshold float64
+
+	// Welford's online mean/variance
+	count int
+	mean  float64
+	m2    float64
+}
+
+// NewZScoreFlag creates a transform that outputs 1 when the incoming
+// value's z-score, measured against the mean and standard deviation of all
+// previous inputs, exceeds threshold in absolute value, and 0 otherwise.
+// Statistics are maintained with Welford's numerically stable online
+// algorithm; every input, flagged or not, is added to them afterwards.
+// During warm-up (the first 10 inputs) the output is always 0.
+// If all previous inputs were identical, any different input is flagged.
+func NewZScoreFlag(threshold float64) *ZScoreFlag {
+	return &ZScoreFlag{threshold: threshold}
+}
+
+// Apply returns 1 if the incoming value is an outlier, else 0.
+func (t *ZScoreFlag) Apply(incoming float64, state State[float64]) float64 {
+	flag := 0.0
+	if t.count >= zScoreWarmup {
+		stddev := math.Sqrt(t.m2 / float64(t.count-1))
+		diff := math.Abs(incoming - t.mean)
+		if stddev == 0 && diff > 0 || stddev > 0 && diff/stddev > t.threshold {
+			flag = 1
+		}
+	}
+
+	t.count++
+	delta := incoming - t.mean
+	t.mean += delta / float64(t.count)
+	t.m2 += delta * (incoming - t.mean)
+
+	return flag
+}
+
+// Clone returns a new ZScoreFlag with the same threshold and no statistics.
+func (t *ZScoreFlag) Clone() Transformation[float64] {
+	return NewZScoreFlag(t.threshold)
+}
+
+// Name returns the transform identifier.
+func (t *ZScoreFlag) Name() string {
+	return "ZScoreFlag"
+}
@@ -0,0 +1,98 @@
+package value
+
+import (
+	"github.com/neox5/simv/clock"
+	"github.com/neox5/simv/internal/broadcast"
+	"github.com/neox5/simv/transform"
+)
+
+// Aggregate creates a value that, on each clock tick, samples all inputs
+// and publishes reduce applied to their current values.
+// Inputs are read with Peek(), so reset-on-read inputs are not reset by
+// sampling. The returned value must be started via Start() like any other.
+// Clock ticks are not fanned out, so clk should not be shared with the
+// sources feeding the inputs.
+func Aggregate[T any](clk clock.Clock, values []*Value[T], reduce func([]T) T) *Value[T] {
+	src := &aggregateSource[T]{
+		clock:  clk,
+		values: values,
+		reduce: reduce,
+	}
+	broadcast.OnFirstSubscribe(&src.broadcaster, src.start)
+	return New[T](src)
+}
+
+// Sum returns the sum of samples. Suitable as an Aggregate reduce function.
+func Sum[T transform.Numeric](samples []T) T {
+	var total T
+	for _, s := range samples {
+		total += s
+	}
+	return total
+}
+
+// Max returns the largest sample, or zero if there are none.
+// Suitable as an Aggregate reduce function.
+func Max[T transform.Numeric](samples []T) T {
+	var result T
+	for i, s := range samples {
+		if i == 0 || s > result {
+			result = s
+		}
+	}
+	return result
+}
+
+// Min returns the smallest sample, or zero if there are none.
+// Suitable as an Aggregate reduce function.
+func Min[T transform.Numeric](samples []T) T {
+	var result T
+	for i, s := range samples {
+		if i == 0 || s < result {
+			result = s
+		}
+	}
+	return result
+}
+
+// aggregateSource samples a set of values on each clock tick.
+type aggregateSource[T any] struct {
+	broadcaster[T]
+
+	clock  clock.Clock
+	values []*Value[T]
+	reduce func([]T) T
+
+	clockChan <-chan struct{}
+}
+
+// start begins sampling, on the first Subscribe.
+func (s *aggregateSource[T]) start() {
+	s.clockChan = s.clock.Subscribe()
+	go s.run()
+}
+
+// Upstreams returns the clock followed by the aggregated values.
+func (s *aggregateSource[T]) Upstreams() []any {
+	ups := []any{s.clock}
+	for _, v := range s.values {
+		ups = append(ups, v)
+	}
+	return ups
+}
+
+func (s *aggregateSource[T]) run() {
+	samples := make([]T, len(s.values))
+
+	for range s.clockChan {
+		for i, v := range s.values {
+			samples[i] = v.Peek()
+		}
+		result := s.reduce(samples)
+
+		broadcast.Publish(&s.broadcaster, result, nil)
+	}
+
+	// Clock closed, close all subscriber channels
+	broadcast.Close(&s.broadcaster)
+}
@@ -0,0 +1,78 @@
+package value
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// alarm is a named threshold condition on the value's state.
+type alarm[T any] struct {
+	name      string
+	predicate func(T) bool
+	onEnter   func()
+	onExit    func()
+	active    atomic.Bool // written only by the update goroutine
+}
+
+// AddAlarm registers a named alarm. After each update, predicate is
+// evaluated on the new state; onEnter is called when it turns from false
+// to true, onExit when it turns from true to false. Alarms start inactive,
+// so a predicate that holds on the first update enters immediately.
+// Either callback may be nil.
+// Predicates and callbacks run on the update goroutine (or the Step
+// caller) after the update lock is released, so they may call Value(),
+// Stats() or AlarmActive(); later updates wait until they return. They run
+// before the update is delivered to subscribers, in registration order.
+// Panics in them are handled according to SetHookPanicMode.
+// Returns the value for method chaining.
+// Panics if name is already used or if called after Start().
+func (v *Value[T]) AddAlarm(name string, predicate func(T) bool, onEnter, onExit func()) *Value[T] {
+	if v.started.Load() {
+		panic("cannot add alarm after Start()")
+	}
+	for _, a := range v.alarms {
+		if a.name == name {
+			panic(fmt.Sprintf("duplicate alarm %q", name))
+		}
+	}
+	v.alarms = append(v.alarms, &alarm[T]{
+		name:      name,
+		predicate: predicate,
+		onEnter:   onEnter,
+		onExit:    onExit,
+	})
+	return v
+}
+
+// AlarmActive reports whether the named alarm's predicate held on the
+// latest update. Returns false for unknown names.
+func (v *Value[T]) AlarmActive(name string) bool {
+	for _, a := range v.alarms {
+		if a.name == name {
+			return a.active.Load()
+		}
+	}
+	return false
+}
+
+// evaluateAlarms checks every alarm against newState and fires the
+// callbacks of those that changed.
+// Must be called without v.mu held.
+func (v *Value[T]) evaluateAlarms(newState T) {
+	for _, a := range v.alarms {
+		var now bool
+		v.safeHookCall(func() { now = a.predicate(newState) })
+		if now == a.active.Load() {
+			continue
+		}
+		a.active.Store(now)
+
+		callback := a.onExit
+		if now {
+			callback = a.onEnter
+		}
+		if callback != nil {
+			v.safeHookCall(callback)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package value
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/neox5/simv/clock"
+	"github.com/neox5/simv/transform"
+)
+
+// Bundle groups correlated values driven by one clock, such as
+// temperature, humidity and pressure of one sensor, under channel names.
+type Bundle struct {
+	clock clock.Clock
+	group *Group
+
+	mu       sync.Mutex
+	names    []string // insertion order
+	channels map[string]Snapshotter
+	started  bool
+}
+
+// NewBundle creates an empty bundle whose channels are driven by clk.
+// The bundle manages the lifecycle of clk and of every channel value.
+func NewBundle(clk clock.Clock) *Bundle {
+	return &Bundle{
+		clock:    clk,
+		group:    NewGroup(),
+		channels: make(map[string]Snapshotter),
+	}
+}
+
+// AddChannel adds a channel named name to b: a value fed by src and
+// processed by transforms, in order. src should be driven by the bundle's
+// clock. The returned value may be configured further before b.Start().
+// AddChannel is a function rather than a method because Go methods cannot
+// have type parameters.
+// Panics if name is already used or if b has been started.
+func AddChannel[T any](b *Bundle, name string, src Publisher[T], transforms ...transform.Transformation[T]) *Value[T] {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.started {
+		panic("cannot add channel after Start()")
+	}
+	if _, exists := b.channels[name]; exists {
+		panic(fmt.Sprintf("bundle: duplicate channel %q", name))
+	}
+
+	v := New(src)
+	for _, t := range transforms {
+		v.AddTransform(t)
+	}
+	b.names = append(b.names, name)
+	b.channels[name] = v
+	b.group.Add(v)
+	return v
+}
+
+// Start starts all channel values, then the clock, so no tick is missed.
+// Panics if called more than once.
+func (b *Bundle) Start() {
+	b.mu.Lock()
+	if b.started {
+		b.mu.Unlock()
+		panic("bundle already started")
+	}
+	b.started = true
+	b.group.AddClock(b.clock)
+	b.mu.Unlock()
+
+	b.group.StartAll()
+}
+
+// Stop stops the clock, then all channel values.
+// Safe to call multiple times.
+func (b *Bundle) Stop() {
+	b.group.StopAll()
+}
+
+// Names returns the channel names in the order they were added.
+func (b *Bundle) Names() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.names...)
+}
+
+// Snapshot reads every channel at one logical instant, keyed by channel
+// name (see SnapshotMany): all channels are locked before any is read, so
+// no update can land between the reads, and reset-on-read is applied as
+// for Value().
+// Channels update independently, so a tick that is still in flight may be
+// reflected in some channels but not yet in others; the snapshot is
+// consistent with respect to completed updates, not to clock ticks.
+func (b *Bundle) Snapshot() map[string]any {
+	b.mu.Lock()
+	names := append([]string(nil), b.names...)
+	values := make([]Snapshotter, len(names))
+	for i, name := range names {
+		values[i] = b.channels[name]
+	}
+	b.mu.Unlock()
+
+	read := SnapshotMany(values...)
+	snapshot := make(map[string]any, len(names))
+	for i, name := range names {
+		snapshot[name] = read[i]
+	}
+	return snapshot
+}
@@ -0,0 +1,98 @@
+package value
+
+import (
+	"errors"
+
+	"github.com/neox5/simv/transform"
+)
+
+// EnableDeltaOnRead makes Value() return the increase of the state since
+// the previous Value() call, while the state itself keeps running: the
+// classic counter-to-delta export, without losing the running total that
+// Peek(), Stats(), subscribers and stateful transforms see.
+//
+// The baseline is shared by all readers: each Value() call, from any
+// goroutine, returns the change since the last Value() call by anyone, and
+// concurrent calls are serialized, so the deltas of all readers together
+// add up to the total change. Readers that need independent deltas should
+// each track their own baseline from Peek(), or read separate values
+// (see Clone). The first read returns the change since Start(), i.e. from
+// the SetInitial baseline. SetCurrent moves the state but not the
+// baseline, so the next delta includes the jump.
+//
+// Not compatible with reset-on-read or lock-free reads; Start() panics
+// (TryStart returns an error) if either is also enabled. With
+// interpolation, deltas are computed from the actual state.
+// Returns the value for method chaining.
+// Panics if T is not a built-in numeric type or if called after Start().
+func (v *Value[T]) EnableDeltaOnRead() *Value[T] {
+	if v.started.Load() {
+		panic("cannot enable delta-on-read after Start()")
+	}
+	sub, ok := subtractFunc[T]()
+	if !ok {
+		panic("delta-on-read requires a numeric Value")
+	}
+	v.delta = sub
+	return v
+}
+
+// validateDelta reports configuration that delta-on-read cannot support.
+func (v *Value[T]) validateDelta() error {
+	if v.delta == nil {
+		return nil
+	}
+	if v.resetOnRead {
+		return errors.New("delta-on-read is not compatible with reset-on-read")
+	}
+	if v.lockFree {
+		return errors.New("delta-on-read is not compatible with lock-free reads")
+	}
+	return nil
+}
+
+// readDelta returns the change since the previous read and advances the
+// baseline. Must be called with v.mu held (locked).
+func (v *Value[T]) readDelta() T {
+	d := v.delta(v.current, v.lastRead)
+	v.lastRead = v.current
+	return d
+}
+
+// subtractFunc returns a function computing a-b for T, or false if T is
+// not a built-in numeric type.
+func subtractFunc[T any]() (func(a, b T) T, bool) {
+	var zero T
+	switch any(zero).(type) {
+	case int:
+		return subtract[T, int], true
+	case int8:
+		return subtract[T, int8], true
+	case int16:
+		return subtract[T, int16], true
+	case int32:
+		return subtract[T, int32], true
+	case int64:
+		return subtract[T, int64], true
+	case uint:
+		return subtract[T, uint], true
+	case uint8:
+		return subtract[T, uint8], true
+	case uint16:
+		return subtract[T, uint16], true
+	case uint32:
+		return subtract[T, uint32], true
+	case uint64:
+		return subtract[T, uint64], true
+	case float32:
+		return subtract[T, float32], true
+	case float64:
+		return subtract[T, float64], true
+	}
+	return nil, false
+}
+
+// subtract returns a-b, where T is known to be N.
+func subtract[T any, N transform.Numeric](a, b T) T {
+	return any(any(a).(N) - any(b).(N)).(T)
+}
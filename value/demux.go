@@ -0,0 +1,260 @@
+package value
+
+import (
+	"sync"
+	"time"
+
+	"github.com/neox5/simv/internal/broadcast"
+	"github.com/neox5/simv/source"
+)
+
+// Demux routes a keyed stream to one value per key, e.g. per-customer
+// metrics from a single multi-tenant source.
+type Demux[K comparable, V any] struct {
+	src         Publisher[source.Keyed[K, V]]
+	setup       func(key K, v *Value[V])
+	idleTimeout time.Duration
+
+	mu      sync.Mutex
+	outputs map[K]*demuxOutput[V]
+	started bool
+
+	srcChan  <-chan source.Keyed[K, V]
+	stop     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// demuxOutput is the value of one key and the source feeding it.
+type demuxOutput[V any] struct {
+	value    *Value[V]
+	src      *keySource[V]
+	lastSeen time.Time // only accessed by run
+}
+
+// NewDemux creates a demultiplexer for src.
+// Values are created on demand: the first emission with a new key creates
+// a value fed by that key's emissions, passes it to setup (if not nil) to
+// add transforms or other configuration, starts it, and then delivers the
+// emission. setup runs on the routing goroutine, so it delays routing
+// while it runs; it must not make the value synchronous.
+// Values are removed by Remove, by SetIdleTimeout, or all at once by Stop.
+// A removed key that is emitted again gets a fresh value. When the source
+// closes, every value finishes but stays available through Get and Keys,
+// holding its final state, until Remove or Stop.
+func NewDemux[K comparable, V any](src Publisher[source.Keyed[K, V]], setup func(key K, v *Value[V])) *Demux[K, V] {
+	return &Demux[K, V]{
+		src:     src,
+		setup:   setup,
+		outputs: make(map[K]*demuxOutput[V]),
+		stop:    make(chan struct{}),
+	}
+}
+
+// SetIdleTimeout removes the value of any key that has not been emitted
+// for d, so keys that disappear from the stream do not accumulate.
+// Idleness is checked as emissions arrive, so a stream that stops entirely
+// removes nothing until Stop. Zero (the default) disables idle removal.
+// Returns the demux for method chaining.
+// Panics if called after Start() or if d is negative.
+func (d *Demux[K, V]) SetIdleTimeout(timeout time.Duration) *Demux[K, V] {
+	if timeout < 0 {
+		panic("demux: idle timeout must not be negative")
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.started {
+		panic("cannot set idle timeout after Start()")
+	}
+	d.idleTimeout = timeout
+	return d
+}
+
+// Start subscribes to the source and begins routing.
+// Returns the demux for method chaining.
+// Panics if called more than once.
+func (d *Demux[K, V]) Start() *Demux[K, V] {
+	d.mu.Lock()
+	if d.started {
+		d.mu.Unlock()
+		panic("demux already started")
+	}
+	d.started = true
+	d.mu.Unlock()
+
+	d.srcChan = d.src.Subscribe()
+	d.wg.Go(d.run)
+	return d
+}
+
+func (d *Demux[K, V]) run() {
+	lastSweep := time.Now()
+
+	for {
+		var kv source.Keyed[K, V]
+		select {
+		case next, ok := <-d.srcChan:
+			if !ok {
+				// Source closed, let every value finish but keep it
+				// readable until Remove or Stop
+				d.finishAll()
+				return
+			}
+			kv = next
+		case <-d.stop:
+			return
+		}
+
+		now := time.Now()
+		out := d.output(kv.Key)
+		out.lastSeen = now
+		if !broadcast.Publish(&out.src.broadcaster, kv.Value, d.stop) {
+			return
+		}
+
+		if d.idleTimeout > 0 && now.Sub(lastSweep) >= d.idleTimeout {
+			d.removeIdle(now)
+			lastSweep = now
+		}
+	}
+}
+
+// output returns the output for key, creating and starting it if needed.
+// Only called by run.
+func (d *Demux[K, V]) output(key K) *demuxOutput[V] {
+	d.mu.Lock()
+	out, ok := d.outputs[key]
+	d.mu.Unlock()
+	if ok {
+		return out
+	}
+
+	src := &keySource[V]{upstream: d.src}
+	v := New[V](src)
+	if d.setup != nil {
+		d.setup(key, v)
+	}
+	v.Start()
+
+	out = &demuxOutput[V]{value: v, src: src}
+	d.mu.Lock()
+	d.outputs[key] = out
+	d.mu.Unlock()
+	return out
+}
+
+// removeIdle removes outputs not emitted since now minus the idle timeout.
+// Only called by run.
+func (d *Demux[K, V]) removeIdle(now time.Time) {
+	d.mu.Lock()
+	var idle []*demuxOutput[V]
+	for key, out := range d.outputs {
+		if now.Sub(out.lastSeen) >= d.idleTimeout {
+			idle = append(idle, out)
+			delete(d.outputs, key)
+		}
+	}
+	d.mu.Unlock()
+
+	for _, out := range idle {
+		out.close()
+	}
+}
+
+// Get returns the value for key, if the key currently has one. After the
+// source closes, the finished values remain until Remove or Stop.
+func (d *Demux[K, V]) Get(key K) (*Value[V], bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	out, ok := d.outputs[key]
+	if !ok {
+		return nil, false
+	}
+	return out.value, true
+}
+
+// Keys returns the keys that currently have a value, in no particular
+// order.
+func (d *Demux[K, V]) Keys() []K {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	keys := make([]K, 0, len(d.outputs))
+	for key := range d.outputs {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Remove stops the value for key and forgets it. Its source channel is
+// closed first, so the value processes every emission already routed to
+// it and its subscribers see a closed channel. Reports whether key had a
+// value.
+func (d *Demux[K, V]) Remove(key K) bool {
+	d.mu.Lock()
+	out, ok := d.outputs[key]
+	delete(d.outputs, key)
+	d.mu.Unlock()
+
+	if ok {
+		out.close()
+	}
+	return ok
+}
+
+// Stop stops routing, unsubscribes from the source and stops and forgets
+// every per-key value. Blocks until all of them have finished.
+// Safe to call multiple times.
+func (d *Demux[K, V]) Stop() {
+	d.stopOnce.Do(func() {
+		close(d.stop)
+		d.wg.Wait()
+		if d.srcChan != nil {
+			release(d.src, d.srcChan)
+		}
+		d.closeAll()
+	})
+}
+
+// finishAll ends the stream of every output, leaving its value to finish
+// on its own. The outputs stay registered.
+func (d *Demux[K, V]) finishAll() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, out := range d.outputs {
+		broadcast.Close(&out.src.broadcaster)
+	}
+}
+
+// closeAll closes and forgets every output.
+func (d *Demux[K, V]) closeAll() {
+	d.mu.Lock()
+	outputs := d.outputs
+	d.outputs = make(map[K]*demuxOutput[V])
+	d.mu.Unlock()
+
+	for _, out := range outputs {
+		out.close()
+	}
+}
+
+// close ends the output's stream and waits for its value to stop.
+func (o *demuxOutput[V]) close() {
+	broadcast.Close(&o.src.broadcaster)
+	o.value.Stop()
+}
+
+// keySource publishes the emissions of one key to its value.
+type keySource[V any] struct {
+	broadcaster[V]
+
+	upstream any // the demultiplexed source, for introspection
+}
+
+// Upstreams returns the demultiplexed source.
+func (s *keySource[V]) Upstreams() []any {
+	return []any{s.upstream}
+}
@@ -0,0 +1,15 @@
+package value
+
+import "errors"
+
+var (
+	// ErrAlreadyStarted is returned by TryStart if the value was started before.
+	ErrAlreadyStarted = errors.New("already started")
+
+	// ErrConfigLocked is returned by configuration methods called after Start().
+	ErrConfigLocked = errors.New("configuration locked after Start()")
+
+	// ErrNilSourceChannel is returned by TryStart if the source's Subscribe
+	// returned a nil channel, e.g. a custom Publisher that was not set up.
+	ErrNilSourceChannel = errors.New("source returned a nil channel from Subscribe()")
+)
@@ -0,0 +1,113 @@
+package value
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Upstreamer is implemented by pipeline nodes that can report the nodes
+// they read from. Values report their source; derived sources report their
+// inputs; clock-driven sources report their clock.
+type Upstreamer interface {
+	Upstreams() []any
+}
+
+// Upstreams returns the value's source.
+func (v *Value[T]) Upstreams() []any {
+	return []any{v.source}
+}
+
+// GraphDOT returns a Graphviz DOT description of the pipeline reachable
+// from roots. It walks upstream references (see Upstreamer), so passing the
+// final values of a simulation draws everything that feeds them. Values are
+// labeled with their transform names. Nodes that do not implement
+// Upstreamer, such as clocks, are leaves. Shared nodes appear once, and
+// cycles are handled by visiting each node only once.
+// Output is deterministic for a given set of roots.
+func GraphDOT(roots ...any) string {
+	g := &dotGraph{ids: make(map[any]string)}
+	for _, r := range roots {
+		g.visit(r)
+	}
+
+	var b strings.Builder
+	b.WriteString("digraph simv {\n")
+	b.WriteString("\trankdir=LR;\n")
+	for _, n := range g.nodes {
+		b.WriteString(n)
+	}
+	for _, e := range g.edges {
+		b.WriteString(e)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotGraph accumulates nodes and edges during a GraphDOT walk.
+type dotGraph struct {
+	ids   map[any]string
+	nodes []string
+	edges []string
+}
+
+// visit adds node and everything upstream of it, returning its DOT ID.
+func (g *dotGraph) visit(node any) string {
+	if node == nil {
+		return ""
+	}
+	// Only comparable nodes can be deduplicated; pipeline nodes are pointers
+	key := node
+	if !reflect.TypeOf(node).Comparable() {
+		key = fmt.Sprintf("%p", node)
+	}
+	if id, ok := g.ids[key]; ok {
+		return id
+	}
+
+	id := fmt.Sprintf("n%d", len(g.ids))
+	g.ids[key] = id
+	g.nodes = append(g.nodes, fmt.Sprintf("\t%s [label=%q, shape=%s];\n", id, dotLabel(node), dotShape(node)))
+
+	if u, ok := node.(Upstreamer); ok {
+		for _, up := range u.Upstreams() {
+			if upID := g.visit(up); upID != "" {
+				g.edges = append(g.edges, fmt.Sprintf("\t%s -> %s;\n", upID, id))
+			}
+		}
+	}
+	return id
+}
+
+// transformNamer is implemented by Value to label DOT nodes.
+type transformNamer interface {
+	transformNames() []string
+}
+
+// transformNames returns the names of the value's transforms in order.
+func (v *Value[T]) transformNames() []string {
+	names := make([]string, len(v.transforms))
+	for i, t := range v.transforms {
+		names[i] = t.Name()
+	}
+	return names
+}
+
+// dotLabel returns the node's type name, plus transform names for values.
+func dotLabel(node any) string {
+	label := strings.TrimPrefix(fmt.Sprintf("%T", node), "*")
+	if tn, ok := node.(transformNamer); ok {
+		if names := tn.transformNames(); len(names) > 0 {
+			label += "\n" + strings.Join(names, "+")
+		}
+	}
+	return label
+}
+
+// dotShape draws values as boxes and everything else as ellipses.
+func dotShape(node any) string {
+	if _, ok := node.(transformNamer); ok {
+		return "box"
+	}
+	return "ellipse"
+}
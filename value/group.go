@@ -0,0 +1,83 @@
+package value
+
+import (
+	"sync"
+
+	"github.com/neox5/simv/clock"
+)
+
+// Member is a component whose lifecycle a Group manages.
+// Implemented by *Value[T] for any T; clocks join via Group.AddClock.
+type Member interface {
+	startMember()
+	Stop()
+}
+
+// Group starts and stops a set of values (and clocks) together.
+type Group struct {
+	mu       sync.Mutex
+	members  []Member
+	stopOnce sync.Once
+}
+
+// NewGroup creates an empty group.
+func NewGroup() *Group {
+	return &Group{}
+}
+
+// Add appends a value to the group.
+// Returns the group for method chaining.
+func (g *Group) Add(m Member) *Group {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.members = append(g.members, m)
+	return g
+}
+
+// AddClock appends a clock to the group.
+// Add clocks after the values they drive, so that values are started
+// before ticks flow and the clock is stopped first.
+// Returns the group for method chaining.
+func (g *Group) AddClock(c clock.Clock) *Group {
+	return g.Add(clockMember{c})
+}
+
+// StartAll starts all members in the order they were added.
+// Panics if a value was already started.
+func (g *Group) StartAll() {
+	for _, m := range g.snapshot() {
+		m.startMember()
+	}
+}
+
+// StopAll stops all members in reverse order, blocking until every value's
+// update goroutine has exited.
+// Safe to call multiple times; only the first call has an effect.
+func (g *Group) StopAll() {
+	g.stopOnce.Do(func() {
+		members := g.snapshot()
+		for i := len(members) - 1; i >= 0; i-- {
+			members[i].Stop()
+		}
+	})
+}
+
+// snapshot returns a copy of the current members.
+func (g *Group) snapshot() []Member {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]Member(nil), g.members...)
+}
+
+// startMember implements Member.
+func (v *Value[T]) startMember() {
+	v.Start()
+}
+
+// clockMember adapts a clock.Clock to Member.
+type clockMember struct {
+	clock clock.Clock
+}
+
+func (c clockMember) startMember() { c.clock.Start() }
+func (c clockMember) Stop()        { c.clock.Stop() }
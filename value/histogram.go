@@ -0,0 +1,86 @@
+package value
+
+import (
+	"slices"
+	"time"
+)
+
+// IntervalHistogram is a snapshot of the time between consecutive updates.
+type IntervalHistogram struct {
+	// Buckets are the upper bounds passed to EnableIntervalHistogram.
+	Buckets []time.Duration
+
+	// Counts[i] is the number of intervals in (Buckets[i-1], Buckets[i]].
+	// The final element, Counts[len(Buckets)], counts intervals above the
+	// last bucket. Counts are per bucket, not cumulative; sum a prefix for
+	// Prometheus-style "le" buckets.
+	Counts []uint64
+
+	// Count is the total number of intervals observed; Sum is their total.
+	Count uint64
+	Sum   time.Duration
+}
+
+// intervalHistogram records inter-update durations.
+// Not safe for concurrent use; guarded by Value.mu.
+type intervalHistogram struct {
+	buckets []time.Duration
+	counts  []uint64
+	sum     time.Duration
+}
+
+// observe records one interval.
+func (h *intervalHistogram) observe(d time.Duration) {
+	i, _ := slices.BinarySearch(h.buckets, d)
+	h.counts[i]++
+	h.sum += d
+}
+
+// EnableIntervalHistogram records the time between consecutive state
+// changes into buckets, exposed via IntervalHistogram(). Unlike
+// UpdateRate, it reveals jitter and stalls in the update path.
+// buckets are upper bounds and must be strictly increasing.
+// Disabled by default, at no cost.
+// Returns the value for method chaining.
+// Panics if buckets is empty or not strictly increasing, or if called
+// after Start().
+func (v *Value[T]) EnableIntervalHistogram(buckets []time.Duration) *Value[T] {
+	if v.started.Load() {
+		panic("cannot enable interval histogram after Start()")
+	}
+	if len(buckets) == 0 {
+		panic("interval histogram requires at least one bucket")
+	}
+	for i := 1; i < len(buckets); i++ {
+		if buckets[i] <= buckets[i-1] {
+			panic("interval histogram buckets must be strictly increasing")
+		}
+	}
+	v.intervals = &intervalHistogram{
+		buckets: slices.Clone(buckets),
+		counts:  make([]uint64, len(buckets)+1),
+	}
+	return v
+}
+
+// IntervalHistogram returns a snapshot of the inter-update durations.
+// The first update has no predecessor and is not counted.
+// Returns the zero IntervalHistogram if the histogram is not enabled.
+func (v *Value[T]) IntervalHistogram() IntervalHistogram {
+	if v.intervals == nil {
+		return IntervalHistogram{}
+	}
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	h := IntervalHistogram{
+		Buckets: slices.Clone(v.intervals.buckets),
+		Counts:  slices.Clone(v.intervals.counts),
+		Sum:     v.intervals.sum,
+	}
+	for _, c := range h.Counts {
+		h.Count += c
+	}
+	return h
+}
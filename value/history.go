@@ -0,0 +1,102 @@
+package value
+
+// history is a ring buffer of the most recent states. Protected by the
+// value's mu.
+type history[T any] struct {
+	buf  []T
+	next int // index of the next write
+	full bool
+}
+
+// add records state, overwriting the oldest entry when full.
+func (h *history[T]) add(state T) {
+	h.buf[h.next] = state
+	h.next++
+	if h.next == len(h.buf) {
+		h.next = 0
+		h.full = true
+	}
+}
+
+// last returns up to n of the newest entries, oldest first.
+func (h *history[T]) last(n int) []T {
+	size := h.next
+	if h.full {
+		size = len(h.buf)
+	}
+	n = min(n, size)
+
+	out := make([]T, n)
+	start := h.next - n
+	if start < 0 {
+		start += len(h.buf)
+	}
+	for i := range out {
+		out[i] = h.buf[(start+i)%len(h.buf)]
+	}
+	return out
+}
+
+// EnableHistory makes the value retain the states produced by its last
+// size updates, readable with History and ExportWindow. Only updates are
+// recorded; SetCurrent and resets by reset-on-read are not.
+// Returns the value for method chaining.
+// Panics if size is not positive or if called after Start().
+func (v *Value[T]) EnableHistory(size int) *Value[T] {
+	if v.started.Load() {
+		panic("cannot enable history after Start()")
+	}
+	if size <= 0 {
+		panic("history size must be positive")
+	}
+	v.history = &history[T]{buf: make([]T, size)}
+	return v
+}
+
+// History returns the retained states, oldest first (see EnableHistory).
+// Reading history never resets the value.
+// Panics if history is not enabled.
+func (v *Value[T]) History() []T {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.requireHistory().last(len(v.history.buf))
+}
+
+// ExportWindow reads the current value like Value(), honoring reset-on-read
+// and delta-on-read, and returns it together with up to n of the most
+// recent history entries, oldest first.
+//
+// Both are taken at one instant, between two updates: the newest entry of
+// recent is the state produced by the last update before the read, and no
+// update can land in between, as it could between separate Value() and
+// History() calls. So with reset-on-read, current is the state accumulated
+// since the previous read, and recent ends with that same state unless
+// SetCurrent changed it since. Fewer than n entries are returned while the
+// history is not yet filled, or if n exceeds its size.
+// Panics if history is not enabled or n is negative.
+func (v *Value[T]) ExportWindow(n int) (current T, recent []T) {
+	if n < 0 {
+		panic("export window: n must not be negative")
+	}
+	v.lockRead()
+	defer v.unlockRead()
+
+	recent = v.requireHistory().last(n)
+	return v.readLocked(), recent
+}
+
+// requireHistory returns the history, panicking if it is not enabled.
+func (v *Value[T]) requireHistory() *history[T] {
+	if v.history == nil {
+		panic("history is not enabled (see EnableHistory)")
+	}
+	return v.history
+}
+
+// recordHistory appends state to the history, if enabled.
+// Must be called with v.mu held (locked).
+func (v *Value[T]) recordHistory(state T) {
+	if v.history != nil {
+		v.history.add(state)
+	}
+}
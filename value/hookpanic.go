@@ -0,0 +1,71 @@
+package value
+
+import (
+	"fmt"
+	"log"
+)
+
+// HookPanicMode controls what happens when an update hook or OnFirstUpdate
+// callback panics.
+type HookPanicMode int
+
+const (
+	// HookPanicLog recovers the panic and reports it via the standard
+	// logger, then continues the update. This is the default.
+	HookPanicLog HookPanicMode = iota
+
+	// HookPanicIgnore recovers the panic silently and continues the update.
+	HookPanicIgnore
+
+	// HookPanicPropagate re-raises the panic, crashing the program (or, for
+	// synchronous values, the Step caller), so buggy hooks fail loudly
+	// during development.
+	HookPanicPropagate
+)
+
+// String returns the mode name.
+func (m HookPanicMode) String() string {
+	switch m {
+	case HookPanicLog:
+		return "Log"
+	case HookPanicIgnore:
+		return "Ignore"
+	case HookPanicPropagate:
+		return "Propagate"
+	default:
+		return fmt.Sprintf("HookPanicMode(%d)", int(m))
+	}
+}
+
+// hookPanic wraps a propagated hook panic so run() re-raises it instead of
+// isolating it like a transform panic.
+type hookPanic struct {
+	value any
+}
+
+func (p hookPanic) String() string {
+	return fmt.Sprintf("simv: update hook panicked: %v", p.value)
+}
+
+// SetHookPanicMode sets how panics in the update hook and the
+// OnFirstUpdate callback are handled (HookPanicLog by default).
+// Returns the value for method chaining.
+// Panics if called after Start().
+func (v *Value[T]) SetHookPanicMode(mode HookPanicMode) *Value[T] {
+	if v.started.Load() {
+		panic("cannot set hook panic mode after Start()")
+	}
+	v.hookPanicMode = mode
+	return v
+}
+
+// handleHookPanic applies the hook panic mode to a recovered panic r.
+func (v *Value[T]) handleHookPanic(r any) {
+	switch v.hookPanicMode {
+	case HookPanicIgnore:
+	case HookPanicPropagate:
+		panic(hookPanic{r})
+	default:
+		log.Printf("simv: value %d: update hook panicked: %v", v.id, r)
+	}
+}
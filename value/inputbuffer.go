@@ -0,0 +1,170 @@
+package value
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// InputOverflow controls what the input buffer does when it is full.
+type InputOverflow int
+
+const (
+	// InputBlock stops reading from the source until the pipeline frees
+	// space, so no input is lost and the source sees backpressure once the
+	// buffer is full. This is the default.
+	InputBlock InputOverflow = iota
+
+	// InputDrop discards source values that arrive while the buffer is
+	// full and counts them in ValueStats.DroppedInputs, so the source is
+	// never blocked by the value.
+	InputDrop
+)
+
+// String returns the mode name.
+func (m InputOverflow) String() string {
+	switch m {
+	case InputBlock:
+		return "Block"
+	case InputDrop:
+		return "Drop"
+	default:
+		return fmt.Sprintf("InputOverflow(%d)", int(m))
+	}
+}
+
+// inputBuffer is the queue between the source and the update goroutine,
+// filled by the pump goroutine.
+type inputBuffer[T any] struct {
+	queue   chan T
+	quit    chan struct{} // closed by run() on exit
+	done    chan struct{} // closed when the pump exits
+	dropped atomic.Uint64
+}
+
+// SetInputBuffer makes the value read from its source into an internal
+// queue of n values, processed by the update goroutine in order. A fast
+// source then keeps being served while the pipeline stalls briefly (a slow
+// hook or subscriber), instead of blocking and, for clock-driven sources,
+// missing ticks. What happens once the queue is full is set by
+// SetInputOverflow. The current queue length is reported by QueueDepth.
+// Zero (the default) disables the buffer.
+// On StopAndDrain, values already in the queue are processed.
+// Not compatible with SetSynchronous.
+// Returns the value for method chaining.
+// Panics if n is negative or if called after Start().
+func (v *Value[T]) SetInputBuffer(n int) *Value[T] {
+	if v.started.Load() {
+		panic("cannot set input buffer after Start()")
+	}
+	if n < 0 {
+		panic("input buffer size must not be negative")
+	}
+	v.inputBufferSize = n
+	return v
+}
+
+// SetInputOverflow sets what a full input buffer does with new source
+// values (InputBlock by default). Has no effect without SetInputBuffer.
+// Returns the value for method chaining.
+// Panics if called after Start().
+func (v *Value[T]) SetInputOverflow(mode InputOverflow) *Value[T] {
+	if v.started.Load() {
+		panic("cannot set input overflow after Start()")
+	}
+	v.inputOverflow = mode
+	return v
+}
+
+// QueueDepth returns the number of source values waiting in the input
+// buffer, or 0 without SetInputBuffer.
+func (v *Value[T]) QueueDepth() int {
+	in := v.input.Load()
+	if in == nil {
+		return 0
+	}
+	return len(in.queue)
+}
+
+// validateInputBuffer reports configuration the input buffer cannot
+// support.
+func (v *Value[T]) validateInputBuffer() error {
+	if v.inputBufferSize > 0 && v.synchronous {
+		return errors.New("synchronous values do not support SetInputBuffer")
+	}
+	return nil
+}
+
+// startInputBuffer starts the pump and returns the channel the update
+// goroutine should read from: the queue, or the source channel itself
+// without a buffer. Called once by TryStart, after subscribing.
+func (v *Value[T]) startInputBuffer() <-chan T {
+	if v.inputBufferSize == 0 {
+		return v.sourceChan
+	}
+
+	in := &inputBuffer[T]{
+		queue: make(chan T, v.inputBufferSize),
+		quit:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	v.input.Store(in)
+	go v.pump(in)
+	return in.queue
+}
+
+// pump moves source values into the queue until the source closes, which
+// it forwards by closing the queue, or run() exits.
+func (v *Value[T]) pump(in *inputBuffer[T]) {
+	defer close(in.done)
+
+	for {
+		var sourceValue T
+		select {
+		case next, ok := <-v.sourceChan:
+			if !ok {
+				close(in.queue)
+				return
+			}
+			sourceValue = next
+		case <-in.quit:
+			return
+		}
+
+		if v.inputOverflow == InputDrop {
+			select {
+			case in.queue <- sourceValue:
+			default:
+				in.dropped.Add(1)
+			}
+			continue
+		}
+		select {
+		case in.queue <- sourceValue:
+		case <-in.quit:
+			return
+		}
+	}
+}
+
+// stopInputBuffer stops the pump and waits for it to exit.
+// Called by run() on exit, so the source channel has no reader left
+// before it is released.
+func (v *Value[T]) stopInputBuffer() {
+	in := v.input.Load()
+	if in == nil {
+		return
+	}
+	close(in.quit)
+	<-in.done
+}
+
+// droppedInputs returns the number of source values dropped by a full
+// input buffer.
+func (v *Value[T]) droppedInputs() uint64 {
+	in := v.input.Load()
+	if in == nil {
+		return 0
+	}
+	return in.dropped.Load()
+}
@@ -0,0 +1,66 @@
+package value
+
+import "time"
+
+// interpolator estimates a float64 value between updates by linear
+// extrapolation from the last two samples.
+// Not safe for concurrent use; guarded by Value.mu.
+type interpolator struct {
+	samples int // number of samples seen, capped at 2
+	prev    float64
+	prevAt  time.Time
+	last    float64
+	lastAt  time.Time
+}
+
+// observe records a new sample taken at now.
+func (ip *interpolator) observe(value float64, now time.Time) {
+	ip.prev, ip.prevAt = ip.last, ip.lastAt
+	ip.last, ip.lastAt = value, now
+	if ip.samples < 2 {
+		ip.samples++
+	}
+}
+
+// estimate returns the extrapolated value at now, or ok=false if there
+// are fewer than two samples.
+func (ip *interpolator) estimate(now time.Time) (float64, bool) {
+	if ip.samples < 2 {
+		return 0, false
+	}
+	span := ip.lastAt.Sub(ip.prevAt).Seconds()
+	if span <= 0 {
+		return 0, false
+	}
+	slope := (ip.last - ip.prev) / span
+	return ip.last + slope*now.Sub(ip.lastAt).Seconds(), true
+}
+
+// EnableInterpolation makes Value() return a linearly interpolated estimate
+// based on the rate between the last two updates and the time elapsed
+// since the last one, instead of repeating the last update.
+// Requires at least two updates; until then Value() returns the last update.
+// Only Value() is affected: Peek() and Stats() report the actual state.
+// Has no effect if reset-on-read is enabled.
+// Returns the value for method chaining.
+// Panics if T is not float64 or if called after Start().
+func (v *Value[T]) EnableInterpolation() *Value[T] {
+	if v.started.Load() {
+		panic("cannot enable interpolation after Start()")
+	}
+	var zero T
+	if _, ok := any(zero).(float64); !ok {
+		panic("interpolation requires Value[float64]")
+	}
+	v.interp = &interpolator{}
+	return v
+}
+
+// interpolated returns the interpolated current value.
+// Must be called with v.mu held (read or write).
+func (v *Value[T]) interpolated() T {
+	if est, ok := v.interp.estimate(time.Now()); ok {
+		return any(est).(T)
+	}
+	return v.current
+}
@@ -0,0 +1,53 @@
+package value
+
+import "errors"
+
+// EnableLockFreeReads makes Value() and Peek() read the current state from
+// an atomic pointer instead of taking the read lock, so read-heavy
+// workloads never contend with the update goroutine or each other.
+// Each state change then allocates a copy of the state; reads that need
+// consistency with other fields (Stats, SnapshotMany) still lock.
+// Not compatible with reset-on-read (reads must mutate state) or
+// interpolation (reads must combine several fields).
+// Returns the value for method chaining.
+// Panics if called after Start().
+func (v *Value[T]) EnableLockFreeReads() *Value[T] {
+	if v.started.Load() {
+		panic("cannot enable lock-free reads after Start()")
+	}
+	v.lockFree = true
+	return v
+}
+
+// validateLockFree reports configuration that lock-free reads cannot support.
+func (v *Value[T]) validateLockFree() error {
+	if !v.lockFree {
+		return nil
+	}
+	if v.resetOnRead {
+		return errors.New("lock-free reads are not compatible with reset-on-read")
+	}
+	if v.interp != nil {
+		return errors.New("lock-free reads are not compatible with interpolation")
+	}
+	return nil
+}
+
+// publishCurrent makes state visible to lock-free readers.
+// Must be called with v.mu held (locked), or before Start().
+func (v *Value[T]) publishCurrent(state T) {
+	if v.lockFree {
+		v.currentPtr.Store(&state)
+	}
+}
+
+// loadCurrent returns the state last published by publishCurrent.
+// Returns false if lock-free reads are disabled or nothing was published
+// yet (before Start), in which case callers fall back to the locked read.
+func (v *Value[T]) loadCurrent() (T, bool) {
+	if p := v.currentPtr.Load(); p != nil {
+		return *p, true
+	}
+	var zero T
+	return zero, false
+}
@@ -0,0 +1,123 @@
+package value
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+
+	"github.com/neox5/simv/transform"
+)
+
+// stateAccumulators lists transforms that fold the incoming value into the
+// current state. More than one of them in a pipeline double counts state,
+// since each reads the same pre-update state via GetState().
+var stateAccumulators = map[string]bool{
+	"Accumulate":           true,
+	"FieldAccumulate":      true,
+	"SaturatingAccumulate": true,
+	"AccumulateProduct":    true,
+}
+
+// runningSums lists transforms that return a running sum held in their own
+// state. A value reset by reset-on-read is overwritten with that sum on the
+// next update, so each reading repeats inputs already reported by the
+// previous one.
+var runningSums = map[string]bool{
+	"BatchSum":    true,
+	"WindowedSum": true,
+}
+
+// checkPipeline validates the pipeline at Start(): in strict mode a
+// problem fails Start, otherwise it is logged as a warning. A product
+// reset to zero can never recover, so it fails Start in either mode.
+func (v *Value[T]) checkPipeline() error {
+	if err := v.validateProductReset(); err != nil {
+		return err
+	}
+	err := v.validatePipeline()
+	if err == nil || v.strictPipeline {
+		return err
+	}
+	log.Printf("simv: value %d: warning: %v", v.id, err)
+	return nil
+}
+
+// validatePipeline checks the transform pipeline for known-bad combinations.
+// Returns a descriptive error for the first problem found.
+// Composite transforms are validated as their flattened children.
+func (v *Value[T]) validatePipeline() error {
+	transforms := flatten(v.transforms)
+
+	first := -1
+	for i, t := range transforms {
+		name := t.Name()
+		if v.resetOnRead && runningSums[name] {
+			return fmt.Errorf("invalid pipeline: %s at position %d double counts with reset-on-read: its running sum survives each reset, so readings overlap",
+				name, i)
+		}
+		if !stateAccumulators[name] {
+			continue
+		}
+		if first >= 0 {
+			prev := transforms[first].Name()
+			if i == first+1 && prev == name {
+				return fmt.Errorf("invalid pipeline: redundant %s at position %d (follows %s at position %d)",
+					name, i, prev, first)
+			}
+			return fmt.Errorf("invalid pipeline: %s at position %d double counts state already accumulated by %s at position %d",
+				name, i, prev, first)
+		}
+		first = i
+	}
+	return nil
+}
+
+// validateProductReset rejects AccumulateProduct combined with reset-on-read
+// to a zero reset value: every product after the first read would be 0.
+func (v *Value[T]) validateProductReset() error {
+	if !v.resetOnRead || !reflect.ValueOf(&v.resetValue).Elem().IsZero() {
+		return nil
+	}
+	for i, t := range flatten(v.transforms) {
+		if t.Name() == "AccumulateProduct" {
+			return fmt.Errorf("invalid pipeline: AccumulateProduct at position %d with reset-on-read to zero stays 0 after the first read; use a reset value of 1",
+				i)
+		}
+	}
+	return nil
+}
+
+// composite is implemented by transforms that bundle child transforms.
+type composite[T any] interface {
+	Transforms() []transform.Transformation[T]
+}
+
+// flatten expands composite transforms into their children, recursively.
+func flatten[T any](ts []transform.Transformation[T]) []transform.Transformation[T] {
+	var out []transform.Transformation[T]
+	for _, t := range ts {
+		if c, ok := t.(composite[T]); ok {
+			out = append(out, flatten(c.Transforms())...)
+			continue
+		}
+		out = append(out, t)
+	}
+	return out
+}
+
+// transformCloser is implemented by transforms that hold background
+// resources, such as a subscription to a secondary stream.
+type transformCloser interface {
+	Close()
+}
+
+// closeTransforms closes every transform implementing transformCloser,
+// including children of composite transforms. Called once when the value
+// finishes.
+func (v *Value[T]) closeTransforms() {
+	for _, t := range flatten(v.transforms) {
+		if c, ok := t.(transformCloser); ok {
+			c.Close()
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package value
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/neox5/simv/clock"
+)
+
+// ResetSharing controls how reset-on-read treats several readers.
+type ResetSharing int
+
+const (
+	// ResetPerRead resets on every Value() call: the first reader gets the
+	// accumulated value and later readers get what accumulated since the
+	// previous read, often just the reset value. This is the default.
+	ResetPerRead ResetSharing = iota
+
+	// ResetPerInterval resets at most once per interval of a clock: the
+	// first Value() call in an interval takes the accumulated value and
+	// resets, and every later call in the same interval returns that same
+	// snapshot, so all readers of an interval agree.
+	ResetPerInterval
+)
+
+// String returns the mode name.
+func (m ResetSharing) String() string {
+	switch m {
+	case ResetPerRead:
+		return "PerRead"
+	case ResetPerInterval:
+		return "PerInterval"
+	default:
+		return fmt.Sprintf("ResetSharing(%d)", int(m))
+	}
+}
+
+// resetShare is the snapshot shared by the readers of one interval.
+// Protected by the value's mu.
+type resetShare[T any] struct {
+	clock    clock.Clock
+	interval uint64 // clock tick count when snapshot was taken
+	snapshot T
+	valid    bool // false until the first read
+}
+
+// SetResetSharing sets how reset-on-read serves several readers. With
+// ResetPerInterval, clk defines the intervals: one interval lasts from a
+// tick of clk to the next, as counted by its Stats().TickCount, which
+// does not consume ticks. Pass the exporter's scrape clock to share one
+// snapshot per scrape, or the clock driving the source to share one per
+// update. Updates within an interval accumulate for the next interval's
+// snapshot, so no update is lost or counted twice. Reads before clk's
+// first tick form an interval of their own.
+// Applies to every read that resets: Value(), SnapshotMany, Bundle
+// snapshots and ExportWindow. clk is ignored for ResetPerRead and may be
+// nil.
+// Requires reset-on-read; Start() panics (TryStart returns an error)
+// otherwise.
+// Returns the value for method chaining.
+// Panics if mode is ResetPerInterval and clk is nil, or if called after
+// Start().
+func (v *Value[T]) SetResetSharing(mode ResetSharing, clk clock.Clock) *Value[T] {
+	if v.started.Load() {
+		panic("cannot set reset sharing after Start()")
+	}
+	switch mode {
+	case ResetPerRead:
+		v.share = nil
+	case ResetPerInterval:
+		if clk == nil {
+			panic("reset sharing: ResetPerInterval requires a clock")
+		}
+		v.share = &resetShare[T]{clock: clk}
+	default:
+		panic(fmt.Sprintf("reset sharing: unknown mode %v", mode))
+	}
+	return v
+}
+
+// validateResetSharing reports configuration that reset sharing cannot
+// support.
+func (v *Value[T]) validateResetSharing() error {
+	if v.share != nil && !v.resetOnRead {
+		return errors.New("reset sharing requires reset-on-read")
+	}
+	return nil
+}
+
+// readShared returns the snapshot of the current interval, taking it and
+// resetting the state if this is the interval's first read.
+// Must be called with v.mu held (locked).
+func (v *Value[T]) readShared() T {
+	s := v.share
+	interval := s.clock.Stats().TickCount
+	if s.valid && s.interval == interval {
+		return s.snapshot
+	}
+
+	s.snapshot = v.current
+	s.interval = interval
+	s.valid = true
+	v.current = v.resetValue
+	return s.snapshot
+}
@@ -0,0 +1,410 @@
+package value
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+)
+
+// Scheduler processes the updates of many values on a fixed pool of worker
+// goroutines, instead of one update goroutine per value.
+type Scheduler struct {
+	jobs chan func()
+	ctrl chan schedCmd
+	quit chan struct{} // closed once the dispatcher has exited
+	wg   sync.WaitGroup
+
+	mu       sync.Mutex
+	values   []schedulable
+	stopping bool
+	stopOnce sync.Once
+}
+
+// schedulable is a value run by a Scheduler, with its type erased.
+type schedulable interface {
+	input() reflect.Value                   // the source channel
+	deliver(sourceValue reflect.Value) bool // false if the value must finish
+	finish(pending reflect.Value, sourceClosed bool)
+	Stop()
+}
+
+// schedCmd is a request to the dispatcher.
+type schedCmd struct {
+	add    schedulable // register (if set) ...
+	remove schedulable // ... or finish and forget
+	reply  chan struct{}
+}
+
+// schedEntry is the dispatcher's bookkeeping for one value.
+type schedEntry struct {
+	member   schedulable
+	busy     bool // a job for it is queued or running
+	slot     int  // index among the idle entries while not busy
+	removing bool // finish once the running job completes
+	finished bool // its finish job was queued
+}
+
+// queuedJob is a source value waiting for a free worker.
+type queuedJob struct {
+	entry *schedEntry
+	value reflect.Value
+}
+
+// jobResult reports a completed job to the dispatcher.
+type jobResult struct {
+	entry *schedEntry
+	alive bool
+}
+
+// NewScheduler creates a scheduler with the given number of workers and
+// starts it. Values join with SetScheduler before their Start().
+//
+// A single dispatcher goroutine receives from the sources of all
+// registered values and hands each received value to a worker, which runs
+// the full update of that value (transforms, hooks, alarms, delivery to
+// subscribers). A value has at most one update in flight and its source is
+// not read meanwhile, so its updates are processed sequentially and in
+// order, with the same backpressure as its own update goroutine would
+// apply. Different values update in parallel, up to workers at a time.
+// The dispatcher waits on all sources at once, so each dispatch costs time
+// linear in the number of values; the scheduler trades some latency for
+// far fewer goroutines. An update blocked on a subscriber that does not
+// read holds its worker, so subscribers of scheduled values must keep up.
+// Panics if workers is not positive.
+func NewScheduler(workers int) *Scheduler {
+	if workers <= 0 {
+		panic("scheduler: workers must be positive")
+	}
+
+	s := &Scheduler{
+		jobs: make(chan func()),
+		ctrl: make(chan schedCmd),
+		quit: make(chan struct{}),
+	}
+	for range workers {
+		s.wg.Go(s.work)
+	}
+	go s.dispatch()
+	return s
+}
+
+// work runs jobs until the dispatcher closes the job channel.
+func (s *Scheduler) work() {
+	for job := range s.jobs {
+		job()
+	}
+}
+
+// dispatch receives source values and schedules jobs until Stop.
+func (s *Scheduler) dispatch() {
+	defer close(s.quit)
+	defer close(s.jobs)
+
+	var (
+		entries []*schedEntry
+		idle    []*schedEntry // not busy; idle[i] is read by cases[firstSource+i]
+		queue   []queuedJob   // jobs waiting for a free worker
+		results = make(chan jobResult)
+		stopped bool
+	)
+
+	const (
+		ctrlCase = iota
+		resultCase
+		jobCase
+		firstSource
+	)
+	// The cases are kept across events and only change when a value
+	// registers, finishes or changes busy state, so a dispatch does not
+	// rebuild them for every value
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(s.ctrl)},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(results)},
+		{Dir: reflect.SelectSend}, // zero Chan: disabled
+	}
+	jobs := reflect.ValueOf(s.jobs)
+
+	// Start reading e's source
+	setIdle := func(e *schedEntry) {
+		e.busy = false
+		e.slot = len(idle)
+		idle = append(idle, e)
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: e.member.input()})
+	}
+	// Stop reading e's source; the last idle entry takes its slot
+	setBusy := func(e *schedEntry) {
+		e.busy = true
+		last := len(idle) - 1
+		moved := idle[last]
+		idle[e.slot] = moved
+		cases[firstSource+e.slot] = cases[firstSource+last]
+		moved.slot = e.slot
+		idle[last] = nil
+		idle = idle[:last]
+		cases = cases[:firstSource+last]
+	}
+	// Queue a job that applies sourceValue
+	deliver := func(e *schedEntry, sourceValue reflect.Value) {
+		setBusy(e)
+		queue = append(queue, queuedJob{e, sourceValue})
+	}
+	// Shutdown bypasses the pool, so stopping a value never waits for
+	// workers held up by other values. pending is a source value received
+	// but not applied yet, if any.
+	finish := func(e *schedEntry, pending reflect.Value, sourceClosed bool) {
+		if !e.busy {
+			setBusy(e)
+		}
+		e.finished = true
+		go func() {
+			e.member.finish(pending, sourceClosed)
+			results <- jobResult{e, false}
+		}()
+	}
+
+	for {
+		if stopped && len(entries) == 0 && len(queue) == 0 {
+			return
+		}
+
+		cases[jobCase].Chan, cases[jobCase].Send = reflect.Value{}, reflect.Value{}
+		if len(queue) > 0 {
+			job := queue[0]
+			cases[jobCase].Chan = jobs
+			cases[jobCase].Send = reflect.ValueOf(func() {
+				results <- jobResult{job.entry, job.entry.member.deliver(job.value)}
+			})
+		}
+
+		chosen, recv, ok := reflect.Select(cases)
+		switch chosen {
+		case ctrlCase:
+			cmd := recv.Interface().(schedCmd)
+			switch {
+			case cmd.add != nil:
+				e := &schedEntry{member: cmd.add}
+				entries = append(entries, e)
+				setIdle(e)
+			case cmd.remove != nil:
+				for _, e := range entries {
+					if e.member != cmd.remove || e.finished {
+						continue
+					}
+					e.removing = true
+					if !e.busy {
+						finish(e, reflect.Value{}, false)
+						continue
+					}
+					// A job still waiting for a worker is handed to
+					// the shutdown instead
+					for i, job := range queue {
+						if job.entry == e {
+							queue = append(queue[:i], queue[i+1:]...)
+							finish(e, job.value, false)
+							break
+						}
+					}
+				}
+			default: // stop: no more commands
+				stopped = true
+				cases[ctrlCase].Chan = reflect.Value{}
+			}
+			close(cmd.reply)
+		case resultCase:
+			res := recv.Interface().(jobResult)
+			e := res.entry
+			switch {
+			case e.finished:
+				entries = without(entries, e)
+			case !res.alive || e.removing:
+				finish(e, reflect.Value{}, false)
+			default:
+				setIdle(e)
+			}
+		case jobCase:
+			queue = queue[1:]
+		default:
+			e := idle[chosen-firstSource]
+			if !ok {
+				finish(e, reflect.Value{}, true)
+				continue
+			}
+			deliver(e, recv)
+		}
+	}
+}
+
+// without returns entries with e removed.
+func without(entries []*schedEntry, e *schedEntry) []*schedEntry {
+	for i, candidate := range entries {
+		if candidate == e {
+			return append(entries[:i], entries[i+1:]...)
+		}
+	}
+	return entries
+}
+
+// send delivers cmd to the dispatcher and waits until it is handled.
+// Returns false if the scheduler has already shut down.
+func (s *Scheduler) send(cmd schedCmd) bool {
+	cmd.reply = make(chan struct{})
+	select {
+	case s.ctrl <- cmd:
+		<-cmd.reply
+		return true
+	case <-s.quit:
+		return false
+	}
+}
+
+// add registers a started value.
+func (s *Scheduler) add(m schedulable) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopping || !s.send(schedCmd{add: m}) {
+		return errScheduler
+	}
+	s.values = append(s.values, m)
+	return nil
+}
+
+// errScheduler is returned by TryStart for a value whose scheduler was
+// stopped.
+var errScheduler = errors.New("scheduler stopped")
+
+// remove finishes a value that is being stopped. Returns after the
+// dispatcher has taken note; the caller waits for the value's Done.
+func (s *Scheduler) remove(m schedulable) {
+	s.send(schedCmd{remove: m})
+}
+
+// Stop stops every value registered with the scheduler, then its workers.
+// Blocks until all of them have exited. Values can no longer be started
+// on the scheduler afterwards.
+// Safe to call multiple times.
+func (s *Scheduler) Stop() {
+	s.stopOnce.Do(func() {
+		s.mu.Lock()
+		s.stopping = true
+		values := s.values
+		s.values = nil
+		s.mu.Unlock()
+
+		for _, m := range values {
+			m.Stop()
+		}
+		s.send(schedCmd{})
+		<-s.quit
+		s.wg.Wait()
+	})
+}
+
+// scheduledValue adapts a Value to the Scheduler.
+type scheduledValue[T any] struct {
+	v *Value[T]
+}
+
+func (sv *scheduledValue[T]) input() reflect.Value {
+	return reflect.ValueOf(sv.v.sourceChan)
+}
+
+// deliver applies one source value. A transform panic finishes the value,
+// as it ends the update goroutine of an unscheduled value.
+func (sv *scheduledValue[T]) deliver(sourceValue reflect.Value) (alive bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			// Hook panics in HookPanicPropagate mode must fail loudly
+			if hp, ok := r.(hookPanic); ok {
+				panic(hp)
+			}
+			alive = false
+		}
+	}()
+	sv.v.apply(sourceValue.Interface().(T))
+	return true
+}
+
+// finish shuts the value down like the end of run(). A pending source
+// value is applied first if the value is draining, and dropped otherwise.
+func (sv *scheduledValue[T]) finish(pending reflect.Value, sourceClosed bool) {
+	v := sv.v
+	if !sourceClosed && v.drainOnStop.Load() {
+		if !pending.IsValid() || sv.deliver(pending) {
+			sourceClosed = sv.drain()
+		}
+	}
+	if !sourceClosed {
+		release(v.source, v.sourceChan)
+	}
+	v.closeTransforms()
+	v.closeSubscribers()
+	close(v.done)
+}
+
+// drain processes available source values for StopAndDrain. Reports
+// whether the source closed; a transform panic ends draining.
+func (sv *scheduledValue[T]) drain() (sourceClosed bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			if hp, ok := r.(hookPanic); ok {
+				panic(hp)
+			}
+			sourceClosed = false
+		}
+	}()
+	return sv.v.drain(nil)
+}
+
+func (sv *scheduledValue[T]) Stop() {
+	sv.v.Stop()
+}
+
+// SetScheduler makes the value's updates run on s's worker pool instead
+// of a dedicated update goroutine (see NewScheduler). Behavior is
+// otherwise unchanged, including Stop, StopAndDrain and Done.
+// Not compatible with SetSynchronous, SetMaxUpdateRate or SetInputBuffer.
+// Returns the value for method chaining.
+// Panics if called after Start().
+func (v *Value[T]) SetScheduler(s *Scheduler) *Value[T] {
+	if v.started.Load() {
+		panic("cannot set scheduler after Start()")
+	}
+	v.scheduler = s
+	return v
+}
+
+// validateScheduler reports configuration a scheduler cannot support.
+func (v *Value[T]) validateScheduler() error {
+	if v.scheduler == nil {
+		return nil
+	}
+	switch {
+	case v.synchronous:
+		return errors.New("synchronous values do not support SetScheduler")
+	case v.maxUpdateInterval > 0:
+		return errors.New("scheduled values do not support SetMaxUpdateRate")
+	case v.inputBufferSize > 0:
+		return errors.New("scheduled values do not support SetInputBuffer")
+	}
+	return nil
+}
+
+// schedule registers a starting value with its scheduler. If the
+// scheduler was stopped, the value finishes right away.
+func (v *Value[T]) schedule() error {
+	v.scheduled = &scheduledValue[T]{v}
+	if err := v.scheduler.add(v.scheduled); err != nil {
+		v.scheduled.finish(reflect.Value{}, false)
+		return err
+	}
+	return nil
+}
+
+// unschedule asks the scheduler to finish a stopping value.
+// Has no effect for unscheduled values.
+func (v *Value[T]) unschedule() {
+	if v.scheduled != nil {
+		v.scheduler.remove(v.scheduled)
+	}
+}
@@ -0,0 +1,90 @@
+package value
+
+import (
+	"bufio"
+	"io"
+	"sync"
+	"time"
+)
+
+// sinkFlushInterval is how often a WriterSink flushes buffered output.
+const sinkFlushInterval = time.Second
+
+// WriterSink records each update of a Value to an io.Writer.
+type WriterSink[T any] struct {
+	updates <-chan T
+	w       *bufio.Writer
+	format  func(T) string
+
+	mu   sync.Mutex
+	err  error
+	done chan struct{}
+}
+
+// NewWriterSink subscribes to v and writes each update to w as one line,
+// formatted by format. Output is buffered and flushed periodically and
+// when the value stops.
+// After the first write error, further output is discarded while updates
+// keep being received so the value is never blocked by a failed writer.
+// The error is reported by Err() and Wait().
+func NewWriterSink[T any](v *Value[T], w io.Writer, format func(T) string) *WriterSink[T] {
+	s := &WriterSink[T]{
+		updates: v.Subscribe(),
+		w:       bufio.NewWriter(w),
+		format:  format,
+		done:    make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+func (s *WriterSink[T]) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(sinkFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case value, ok := <-s.updates:
+			if !ok {
+				// Value stopped, flush remaining output
+				s.setErr(s.w.Flush())
+				return
+			}
+			if s.Err() != nil {
+				continue
+			}
+			if _, err := s.w.WriteString(s.format(value) + "\n"); err != nil {
+				s.setErr(err)
+			}
+		case <-ticker.C:
+			if s.Err() == nil {
+				s.setErr(s.w.Flush())
+			}
+		}
+	}
+}
+
+// Err returns the first write error, or nil.
+func (s *WriterSink[T]) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Wait blocks until the value stops and all output is flushed.
+// Returns the first write error, or nil.
+func (s *WriterSink[T]) Wait() error {
+	<-s.done
+	return s.Err()
+}
+
+// setErr records err if it is the first error.
+func (s *WriterSink[T]) setErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err == nil {
+		s.err = err
+	}
+}
@@ -0,0 +1,68 @@
+package value
+
+import (
+	"sort"
+	"sync/atomic"
+)
+
+// nextID issues unique value IDs.
+var nextID atomic.Uint64
+
+// Snapshotter is a value that can take part in SnapshotMany.
+// Implemented by *Value[T] for any T.
+type Snapshotter interface {
+	snapshotID() uint64
+	lockRead()
+	readAny() any
+	unlockRead()
+}
+
+// SnapshotMany reads several values at one logical instant.
+// All values are locked before any is read, so no update can land between
+// the reads, and each result is exactly what Value() would return
+// (reset-on-read is applied). Results are in argument order; a value
+// passed more than once is read once and repeated.
+// Locks are acquired in a global order (value creation order), so
+// concurrent SnapshotMany calls over overlapping sets cannot deadlock.
+// Locks are held only for the duration of the reads.
+func SnapshotMany(values ...Snapshotter) []any {
+	// Unique values in lock order
+	byID := make(map[uint64]Snapshotter, len(values))
+	for _, v := range values {
+		byID[v.snapshotID()] = v
+	}
+	ordered := make([]Snapshotter, 0, len(byID))
+	for _, v := range byID {
+		ordered = append(ordered, v)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].snapshotID() < ordered[j].snapshotID()
+	})
+
+	for _, v := range ordered {
+		v.lockRead()
+	}
+	read := make(map[uint64]any, len(ordered))
+	for _, v := range ordered {
+		read[v.snapshotID()] = v.readAny()
+	}
+	for i := len(ordered) - 1; i >= 0; i-- {
+		ordered[i].unlockRead()
+	}
+
+	results := make([]any, len(values))
+	for i, v := range values {
+		results[i] = read[v.snapshotID()]
+	}
+	return results
+}
+
+// snapshotID implements Snapshotter.
+func (v *Value[T]) snapshotID() uint64 {
+	return v.id
+}
+
+// readAny implements Snapshotter.
+func (v *Value[T]) readAny() any {
+	return v.readLocked()
+}
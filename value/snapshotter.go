@@ -0,0 +1,36 @@
+package value
+
+import "time"
+
+// StartSnapshotter spawns a goroutine that reads the value via Value()
+// every interval and passes the result to sink, the canonical export loop.
+// Reset-on-read is honored, so each reading covers one interval.
+// When the value stops, one final reading is passed to sink so no updates
+// are lost, then the goroutine exits.
+// sink runs on the snapshotter goroutine; a slow sink delays later readings.
+// Returns the value for method chaining.
+// Panics if interval is not positive or sink is nil.
+func (v *Value[T]) StartSnapshotter(interval time.Duration, sink func(T)) *Value[T] {
+	if interval <= 0 {
+		panic("snapshotter: interval must be positive")
+	}
+	if sink == nil {
+		panic("snapshotter: sink must not be nil")
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				sink(v.Value())
+			case <-v.done:
+				sink(v.Value())
+				return
+			}
+		}
+	}()
+	return v
+}
@@ -0,0 +1,89 @@
+package value
+
+// SetSynchronous configures the value for synchronous stepping.
+// Start() then subscribes to the source but does not start an update
+// goroutine; instead, each Step() call receives and processes exactly one
+// source value on the caller's goroutine. Value(), Stats() and reset-on-read
+// behave as usual.
+// Not compatible with SetMaxUpdateRate.
+// Returns the value for method chaining.
+// Panics if called after Start().
+func (v *Value[T]) SetSynchronous() *Value[T] {
+	if v.started.Load() {
+		panic("cannot set synchronous after Start()")
+	}
+	v.synchronous = true
+	return v
+}
+
+// Step blocks until the source emits, then processes that value through
+// the pipeline before returning. Returns false, without processing, if the
+// source channel is closed or the value has been stopped.
+// Transform panics propagate to the caller.
+// Panics if the value is not synchronous or not started.
+func (v *Value[T]) Step() bool {
+	if !v.synchronous {
+		panic("Step requires SetSynchronous()")
+	}
+	if !v.started.Load() {
+		panic("Step called before Start()")
+	}
+
+	v.stepMu.Lock()
+	defer v.stepMu.Unlock()
+
+	if v.sourceClosed {
+		return false
+	}
+
+	// Stop takes precedence over an available source value
+	select {
+	case <-v.stop:
+		return false
+	default:
+	}
+
+	select {
+	case sourceValue, ok := <-v.sourceChan:
+		if !ok {
+			v.sourceClosed = true
+			v.finishSync()
+			return false
+		}
+		v.apply(sourceValue)
+		return true
+	case <-v.stop:
+		return false
+	}
+}
+
+// stopSync stops a synchronous value, waiting for an in-flight Step.
+// If drain is set, source values already available are processed first.
+func (v *Value[T]) stopSync(drain bool) {
+	close(v.stop)
+
+	v.stepMu.Lock()
+	defer v.stepMu.Unlock()
+
+	if v.sourceClosed {
+		return
+	}
+	if drain {
+		v.sourceClosed = v.drain(nil)
+	}
+	if !v.sourceClosed && v.sourceChan != nil {
+		// Make sure the source is never blocked on us
+		release(v.source, v.sourceChan)
+	}
+	v.finishSync()
+}
+
+// finishSync releases subscribers and marks the value done.
+// Must be called with v.stepMu held.
+func (v *Value[T]) finishSync() {
+	v.syncDoneOnce.Do(func() {
+		v.closeTransforms()
+		v.closeSubscribers()
+		close(v.done)
+	})
+}
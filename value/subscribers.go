@@ -0,0 +1,24 @@
+package value
+
+import "github.com/neox5/simv/internal/broadcast"
+
+// broadcaster is embedded by values and value-backed sources to provide
+// Unsubscribe and SubscribeWithCancel with the semantics documented on
+// broadcast.Broadcaster.
+type broadcaster[T any] = broadcast.Broadcaster[T]
+
+// unsubscriber is implemented by publishers that support Unsubscribe.
+type unsubscriber[T any] interface {
+	Unsubscribe(ch <-chan T)
+}
+
+// release stops consuming ch from pub. Publishers that support Unsubscribe
+// are told to stop sending; for others, ch is drained in the background
+// until pub closes it, so pub is never blocked on an abandoned channel.
+func release[T any](pub Publisher[T], ch <-chan T) {
+	if u, ok := pub.(unsubscriber[T]); ok {
+		u.Unsubscribe(ch)
+		return
+	}
+	go discard(ch)
+}
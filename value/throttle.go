@@ -0,0 +1,58 @@
+package value
+
+import "time"
+
+// throttle coalesces inputs so that at most one is applied per interval.
+// Inputs arriving within the window replace each other; the latest one is
+// applied when the window ends. Not safe for concurrent use.
+// A nil throttle never holds inputs.
+type throttle[T any] struct {
+	interval time.Duration
+
+	lastApplied time.Time
+	pending     T
+	hasPending  bool
+	timer       *time.Timer
+}
+
+// offer submits an input received at now.
+// Returns true if the input should be applied immediately. Otherwise it is
+// held as pending; coalesced reports whether it replaced an earlier pending input.
+func (t *throttle[T]) offer(input T, now time.Time) (applyNow, coalesced bool) {
+	if !t.hasPending {
+		if wait := t.interval - now.Sub(t.lastApplied); wait > 0 {
+			t.pending = input
+			t.hasPending = true
+			t.timer = time.NewTimer(wait)
+			return false, false
+		}
+		t.lastApplied = now
+		return true, false
+	}
+
+	t.pending = input
+	return false, true
+}
+
+// ready returns a channel that fires when a pending input is due,
+// or nil if nothing is pending.
+func (t *throttle[T]) ready() <-chan time.Time {
+	if t == nil || !t.hasPending {
+		return nil
+	}
+	return t.timer.C
+}
+
+// take returns the pending input and marks it applied at now.
+func (t *throttle[T]) take(now time.Time) (T, bool) {
+	var zero T
+	if t == nil || !t.hasPending {
+		return zero, false
+	}
+	input := t.pending
+	t.pending = zero
+	t.hasPending = false
+	t.timer.Stop()
+	t.lastApplied = now
+	return input, true
+}
@@ -1,9 +1,15 @@
 package value
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
+	"github.com/neox5/simv/internal/broadcast"
 	"github.com/neox5/simv/transform"
 )
 
@@ -17,11 +23,27 @@ type ValueStats[T any] struct {
 	UpdateCount    uint64
 	CurrentValue   T
 	TransformCount int
+	ResetOnRead    bool
+	ResetValue     T
+	CoalescedCount uint64
+
+	// QueueDepth and DroppedInputs report the input buffer (see
+	// SetInputBuffer): values waiting in it, and values dropped because it
+	// was full under InputDrop.
+	QueueDepth    int
+	DroppedInputs uint64
+
+	// MaxUpdateDuration is the longest time a single update took in the
+	// update goroutine, including transforms, hooks and delivery to
+	// subscribers.
+	MaxUpdateDuration time.Duration
 }
 
 // Value represents a thread-safe simulated value with configurable behavior.
 // Values must be explicitly started via Start() after configuration.
 type Value[T any] struct {
+	id uint64 // unique, orders locking in SnapshotMany
+
 	// Configuration (immutable after Start)
 	source     Publisher[T]
 	transforms []transform.Transformation[T]
@@ -29,28 +51,107 @@ type Value[T any] struct {
 	// Reset behavior
 	resetOnRead bool
 	resetValue  T
+	share       *resetShare[T] // nil for ResetPerRead
+
+	// Delta-on-read (nil if disabled; lastRead protected by mu)
+	delta    func(a, b T) T
+	lastRead T
+
+	// Baseline before the first update (see SetInitial)
+	initial T
+
+	// Validation
+	strictPipeline bool
+
+	// Gating (drops source values while closed)
+	gate func() bool
+
+	// Rate limiting (coalesces source values)
+	maxUpdateInterval time.Duration
+
+	// Input buffering (queue between source and update goroutine)
+	inputBufferSize int
+	inputOverflow   InputOverflow
+
+	// Worker pool running updates instead of run() (nil if unscheduled)
+	scheduler *Scheduler
+	scheduled *scheduledValue[T]
+
+	// Run time limit (stops the value after this long from Start)
+	maxDuration time.Duration
+
+	// Interpolation (float64 only, protected by mu)
+	interp *interpolator
+
+	// Inter-update durations (protected by mu)
+	intervals *intervalHistogram
+
+	// Recent states (nil if disabled, protected by mu)
+	history *history[T]
+
+	// Synchronous stepping (no update goroutine)
+	synchronous  bool
+	stepMu       sync.Mutex // serializes Step and stop
+	sourceClosed bool       // protected by stepMu
+	syncDoneOnce sync.Once
+
+	// Profiling (cumulative Apply time per transform, protected by mu)
+	profiling   bool
+	profileTime []time.Duration
 
 	// Lifecycle
-	sourceChan <-chan T
-	started    atomic.Bool
-	stopOnce   sync.Once
-	done       chan struct{}
+	sourceChan  <-chan T
+	inputChan   <-chan T                       // read by run(): sourceChan or the input buffer
+	input       atomic.Pointer[inputBuffer[T]] // nil without SetInputBuffer; set by Start()
+	started     atomic.Bool
+	startMu     sync.Mutex   // serializes TryStart
+	startTime   atomic.Int64 // UnixNano, set by Start()
+	stopOnce    sync.Once
+	stop        chan struct{}
+	drainOnStop atomic.Bool
+	done        chan struct{}
+
+	// First update signal (closed after the first setState)
+	firstOnce   sync.Once
+	firstUpdate chan struct{}
+
+	// First update callback (fired once by run())
+	onFirstUpdate func(T)
+	firstFired    bool
+
+	// Threshold alarms (evaluated by run() after each update)
+	alarms []*alarm[T]
+
+	// Lock-free reads (current mirrored in currentPtr after each change)
+	lockFree   bool
+	currentPtr atomic.Pointer[T]
 
 	// State (mutable, protected by mu)
-	mu          sync.RWMutex
-	current     T
-	updateCount atomic.Uint64
+	mu             sync.RWMutex
+	current        T
+	lastInput      T
+	lastUpdate     time.Time
+	updateCount    atomic.Uint64
+	coalescedCount atomic.Uint64
+	maxUpdateNanos atomic.Int64 // written only by run()
+
+	// Subscribers (receive state after each update)
+	broadcaster[T]
 
 	// Observability
-	updateHook atomic.Value // stores UpdateHook[T]
+	updateHook    atomic.Value // stores UpdateHook[T]
+	hookPanicMode HookPanicMode
 }
 
 // New creates a new Value that will receive values from the given source.
 // The value must be started via Start() before it begins receiving updates.
 func New[T any](src Publisher[T]) *Value[T] {
 	return &Value[T]{
-		source: src,
-		done:   make(chan struct{}),
+		id:          nextID.Add(1),
+		source:      src,
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+		firstUpdate: make(chan struct{}),
 	}
 }
 
@@ -58,22 +159,166 @@ func New[T any](src Publisher[T]) *Value[T] {
 // Returns the value for method chaining.
 // Panics if called after Start().
 func (v *Value[T]) AddTransform(t transform.Transformation[T]) *Value[T] {
+	if err := v.TryAddTransform(t); err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// TryAddTransform is like AddTransform but returns an error wrapping
+// ErrConfigLocked instead of panicking if called after Start().
+func (v *Value[T]) TryAddTransform(t transform.Transformation[T]) error {
 	if v.started.Load() {
-		panic("cannot add transform after Start()")
+		return fmt.Errorf("cannot add transform: %w", ErrConfigLocked)
 	}
 	v.transforms = append(v.transforms, t)
-	return v
+	return nil
 }
 
 // EnableResetOnRead configures the value to reset to resetValue on each Value() call.
 // Returns the value for method chaining.
 // Panics if called after Start().
 func (v *Value[T]) EnableResetOnRead(resetValue T) *Value[T] {
+	if err := v.TryEnableResetOnRead(resetValue); err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// TryEnableResetOnRead is like EnableResetOnRead but returns an error
+// wrapping ErrConfigLocked instead of panicking if called after Start().
+func (v *Value[T]) TryEnableResetOnRead(resetValue T) error {
 	if v.started.Load() {
-		panic("cannot enable reset-on-read after Start()")
+		return fmt.Errorf("cannot enable reset-on-read: %w", ErrConfigLocked)
 	}
 	v.resetOnRead = true
 	v.resetValue = resetValue
+	return nil
+}
+
+// SetResetValue changes the value Value() resets to under reset-on-read.
+// Unlike other configuration it may be called before or after Start(), so
+// an exporter can switch baselines at runtime, e.g. passing the last
+// exported value for "reset to current" delta semantics.
+// The change is atomic with respect to reads: each Value() call resets to
+// either the old or the new value, never a mix, and the next call after
+// SetResetValue returns uses the new one. The current state is not
+// changed, so the first read afterwards still returns the state
+// accumulated so far.
+// Panics if reset-on-read is not enabled.
+func (v *Value[T]) SetResetValue(resetValue T) {
+	if !v.resetOnRead {
+		panic("cannot set reset value: reset-on-read is not enabled")
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.resetValue = resetValue
+}
+
+// SetInitial sets the baseline state the value holds before its first
+// update. Reads return initial instead of the zero value, and stateful
+// transforms start from it (an Accumulate total starts at initial).
+// The baseline is not an update: UpdateCount stays 0, Health reports no
+// update and WaitValue keeps waiting for real data.
+// With reset-on-read, the first read returns initial once and then resets.
+// Returns the value for method chaining.
+// Panics if called after Start().
+func (v *Value[T]) SetInitial(initial T) *Value[T] {
+	if v.started.Load() {
+		panic("cannot set initial value after Start()")
+	}
+	v.initial = initial
+	v.current = initial
+	return v
+}
+
+// SetStrictPipeline makes pipeline validation at Start() strict.
+// The transform pipeline is always checked for known-bad combinations,
+// such as redundant accumulators or a running sum combined with
+// reset-on-read (see validatePipeline). By default a problem is only
+// logged as a warning; when strict, Start() panics (TryStart returns an
+// error) with the descriptive error instead.
+// Returns the value for method chaining.
+// Panics if called after Start().
+func (v *Value[T]) SetStrictPipeline(strict bool) *Value[T] {
+	if v.started.Load() {
+		panic("cannot set strict pipeline after Start()")
+	}
+	v.strictPipeline = strict
+	return v
+}
+
+// SetGate sets a gate that controls whether source values are applied.
+// While gate returns false, incoming source values are dropped: they are
+// not transformed and not counted in UpdateCount.
+// The gate is evaluated under the update lock on every source value,
+// so it should be cheap and must not call back into the value.
+// Returns the value for method chaining.
+// Panics if called after Start().
+func (v *Value[T]) SetGate(gate func() bool) *Value[T] {
+	if v.started.Load() {
+		panic("cannot set gate after Start()")
+	}
+	v.gate = gate
+	return v
+}
+
+// SetMaxUpdateRate limits state changes to at most one per interval.
+// Source values arriving within an interval are coalesced: only the latest
+// one is applied when the interval ends, and each replaced value is counted
+// in CoalescedCount. Unlike a slower clock, the source keeps generating at
+// its own rate. A non-positive interval disables rate limiting.
+// Returns the value for method chaining.
+// Panics if called after Start().
+func (v *Value[T]) SetMaxUpdateRate(interval time.Duration) *Value[T] {
+	if v.started.Load() {
+		panic("cannot set max update rate after Start()")
+	}
+	v.maxUpdateInterval = interval
+	return v
+}
+
+// SetMaxDuration stops the value automatically once d has passed since
+// Start(), as if Stop() were called then, so time-boxed runs such as soak
+// tests terminate on their own regardless of the clock. The value shuts
+// down as for Stop(), closing Done() and subscriber channels; Value()
+// keeps returning the last state. A non-positive d disables the limit.
+// Returns the value for method chaining.
+// Panics if called after Start().
+func (v *Value[T]) SetMaxDuration(d time.Duration) *Value[T] {
+	if v.started.Load() {
+		panic("cannot set max duration after Start()")
+	}
+	v.maxDuration = d
+	return v
+}
+
+// OnFirstUpdate registers fn to be called exactly once, with the new state,
+// after the first update from the source.
+// fn runs on the update goroutine after the update lock is released, so it
+// may call Value() or Stats(); later updates wait until it returns.
+// Panics in fn are handled according to SetHookPanicMode.
+// Returns the value for method chaining.
+// Panics if called after Start().
+func (v *Value[T]) OnFirstUpdate(fn func(T)) *Value[T] {
+	if v.started.Load() {
+		panic("cannot set first update callback after Start()")
+	}
+	v.onFirstUpdate = fn
+	return v
+}
+
+// EnableTransformProfiling records the cumulative time spent in each
+// transform's Apply, exposed via TransformProfile().
+// Adds two time.Now() calls per transform per update; no cost when disabled.
+// Returns the value for method chaining.
+// Panics if called after Start().
+func (v *Value[T]) EnableTransformProfiling() *Value[T] {
+	if v.started.Load() {
+		panic("cannot enable transform profiling after Start()")
+	}
+	v.profiling = true
 	return v
 }
 
@@ -92,43 +337,427 @@ func (v *Value[T]) SetUpdateHook(hook UpdateHook[T]) *Value[T] {
 // Start begins receiving updates from the source.
 // Locks configuration - no further AddTransform or EnableResetOnRead calls allowed.
 // Returns the value for method chaining.
-// Panics if already started.
+// Synchronous values (see SetSynchronous) start no goroutine.
+// Panics if already started, or if strict pipeline validation fails.
 func (v *Value[T]) Start() *Value[T] {
-	if !v.started.CompareAndSwap(false, true) {
-		panic("already started")
+	if err := v.TryStart(); err != nil {
+		panic(err)
 	}
+	return v
+}
+
+// TryStart is like Start but returns an error instead of panicking:
+// ErrAlreadyStarted if the value was already started (safe under
+// concurrent calls; exactly one succeeds), or a configuration error.
+// If the source returns a nil channel from Subscribe(), TryStart returns
+// ErrNilSourceChannel and the value is finished immediately: Done() is
+// closed and Stop() returns without blocking.
+func (v *Value[T]) TryStart() error {
+	// Validate only on the call that starts the value, so a repeated
+	// Start does not log pipeline warnings again
+	v.startMu.Lock()
+	defer v.startMu.Unlock()
+	if v.started.Load() {
+		return ErrAlreadyStarted
+	}
+	if err := v.checkPipeline(); err != nil {
+		return err
+	}
+	if v.synchronous && v.maxUpdateInterval > 0 {
+		return errors.New("synchronous values do not support SetMaxUpdateRate")
+	}
+	if err := v.validateLockFree(); err != nil {
+		return err
+	}
+	if err := v.validateDelta(); err != nil {
+		return err
+	}
+	if err := v.validateResetSharing(); err != nil {
+		return err
+	}
+	if err := v.validateInputBuffer(); err != nil {
+		return err
+	}
+	if err := v.validateScheduler(); err != nil {
+		return err
+	}
+	v.started.Store(true)
+	v.publishCurrent(v.current)
+	v.lastRead = v.current
+	v.startTime.Store(time.Now().UnixNano())
 	v.sourceChan = v.source.Subscribe()
-	go v.run()
+	if v.sourceChan == nil {
+		// A nil channel never delivers: finish now instead of leaving
+		// Stop() and Done() waiting on an update loop that cannot end
+		v.stepMu.Lock()
+		defer v.stepMu.Unlock()
+		v.sourceClosed = true
+		v.finishSync()
+		return ErrNilSourceChannel
+	}
+	switch {
+	case v.scheduler != nil:
+		if err := v.schedule(); err != nil {
+			return err
+		}
+	case !v.synchronous:
+		v.inputChan = v.startInputBuffer()
+		go v.run()
+	}
+	if v.maxDuration > 0 {
+		go v.stopAfter(v.maxDuration)
+	}
+	return nil
+}
+
+// stopAfter stops the value after d, unless it finishes first.
+func (v *Value[T]) stopAfter(d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		v.Stop()
+	case <-v.done:
+	}
+}
+
+// StartContext starts the value like Start and stops it when ctx is
+// cancelled, so a whole pipeline can be tied to a request or job context.
+// Returns the value for method chaining.
+// Panics if already started, or if strict pipeline validation fails.
+func (v *Value[T]) StartContext(ctx context.Context) *Value[T] {
+	v.Start()
+	go func() {
+		select {
+		case <-ctx.Done():
+			v.Stop()
+		case <-v.done:
+		}
+	}()
 	return v
 }
 
+// Subscribe returns a channel that receives the value's state after each update.
+// Implements Publisher[T], so values can feed other values.
+// Sends block until received (or the value is stopped or the channel is
+// unsubscribed), so subscribers must keep up with updates.
+// The channel is closed when the update goroutine exits. Unsubscribe and
+// SubscribeWithCancel release channels early, for dynamic subscriber sets.
+func (v *Value[T]) Subscribe() <-chan T {
+	return v.broadcaster.Subscribe()
+}
+
+// SubscribeWithReplay is like Subscribe, but the channel first receives
+// the current state, so a late subscriber such as a dashboard has data
+// before the next update. Then it receives every later update, exactly
+// once: the replayed state and the update stream are taken atomically, so
+// an update racing with the call is either reflected in the replayed
+// state or delivered afterwards, never both and never neither.
+// The replayed state is read like Peek(): reset-on-read is not applied.
+// The channel buffers one value. On a value that has finished, the channel
+// receives the final state and is then closed.
+func (v *Value[T]) SubscribeWithReplay() <-chan T {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	// update() holds mu while changing state and counting the update
+	return broadcast.SubscribeReplay(&v.broadcaster, v.current, v.updateCount.Load())
+}
+
+// Derive returns a new, already started value that subscribes to v and
+// applies t to each of v's updates. v itself is not modified, so this
+// works after v is started.
+// The caller owns the derived value and should Stop() it when done; it
+// also finishes on its own when v stops.
+func (v *Value[T]) Derive(t transform.Transformation[T]) *Value[T] {
+	return New[T](v).AddTransform(t).Start()
+}
+
+// Clone returns a new, not yet started value with the same source and
+// configuration as v, but independent state. Transforms are cloned with
+// transform.CloneOf: stateful transforms (those implementing
+// transform.Cloner, such as accumulating or windowed built-ins) start
+// fresh, while stateless ones are shared. The clone starts from the
+// SetInitial baseline, not from v's current state, and with an empty
+// history. Gate, OnFirstUpdate and alarm functions are shared (alarms
+// start inactive); the update hook is not copied, since hooks may keep
+// per-update state.
+// Safe to call before or after Start(); the clone can be further
+// configured before its own Start().
+func (v *Value[T]) Clone() *Value[T] {
+	c := New(v.source)
+	for _, t := range v.transforms {
+		c.transforms = append(c.transforms, transform.CloneOf(t))
+	}
+	c.resetOnRead = v.resetOnRead
+	v.mu.RLock() // SetResetValue may run concurrently
+	c.resetValue = v.resetValue
+	v.mu.RUnlock()
+	if v.share != nil {
+		c.SetResetSharing(ResetPerInterval, v.share.clock)
+	}
+	c.delta = v.delta
+	c.initial = v.initial
+	c.current = v.initial
+	c.strictPipeline = v.strictPipeline
+	c.gate = v.gate
+	c.maxUpdateInterval = v.maxUpdateInterval
+	c.maxDuration = v.maxDuration
+	c.inputBufferSize = v.inputBufferSize
+	c.inputOverflow = v.inputOverflow
+	c.scheduler = v.scheduler
+	if v.interp != nil {
+		c.interp = &interpolator{}
+	}
+	if v.intervals != nil {
+		c.EnableIntervalHistogram(v.intervals.buckets)
+	}
+	if v.history != nil {
+		c.EnableHistory(len(v.history.buf))
+	}
+	c.synchronous = v.synchronous
+	c.profiling = v.profiling
+	c.lockFree = v.lockFree
+	c.hookPanicMode = v.hookPanicMode
+	c.onFirstUpdate = v.onFirstUpdate
+	for _, a := range v.alarms {
+		c.AddAlarm(a.name, a.predicate, a.onEnter, a.onExit)
+	}
+	return c
+}
+
 // Stop stops receiving updates and releases resources.
-// Blocks until the update goroutine exits.
+// Stopping is abrupt: source values not yet received are dropped, and any
+// input held back by SetMaxUpdateRate is discarded. Use StopAndDrain to
+// process already emitted values first.
+//
+// Shutdown order: the value unsubscribes from its source, so the source
+// stops sending to it (sources without Unsubscribe are drained in the
+// background until they close instead), then closes its transforms, then
+// closes its subscriber channels. Stop blocks until the update goroutine
+// has exited, so no goroutine of this value outlives it.
 // Safe to call multiple times.
 func (v *Value[T]) Stop() {
 	v.stopOnce.Do(func() {
+		if v.synchronous {
+			v.stopSync(false)
+			return
+		}
+		close(v.stop)
+		v.unschedule()
 		// Wait for run() to finish and close done channel
 		<-v.done
 	})
 }
 
+// StopAndDrain stops receiving updates like Stop, but first processes all
+// source values that are already available, including an input pending
+// from SetMaxUpdateRate, so the final state reflects everything emitted
+// before the call. It does not wait for the source to emit new values.
+// Blocks until the update goroutine exits.
+// Safe to call multiple times; only the first Stop or StopAndDrain call
+// determines whether values are drained.
+func (v *Value[T]) StopAndDrain() {
+	v.stopOnce.Do(func() {
+		if v.synchronous {
+			v.stopSync(true)
+			return
+		}
+		v.drainOnStop.Store(true)
+		close(v.stop)
+		v.unschedule()
+		<-v.done
+	})
+}
+
+// Done returns a channel that is closed once the value has finished: its
+// source closed, or it was stopped (Stop, StopAndDrain, StartContext
+// cancellation or SetMaxDuration). Subscriber channels are closed and the
+// final state is readable by then. Never closed for a value that is not
+// started. For synchronous values, it is closed when Step observes the
+// closed source or on Stop.
+func (v *Value[T]) Done() <-chan struct{} {
+	return v.done
+}
+
 // Value returns the current value.
 // If reset-on-read is enabled, atomically reads and resets the value.
 func (v *Value[T]) Value() T {
-	if v.resetOnRead {
+	if state, ok := v.loadCurrent(); ok {
+		return state
+	}
+	v.lockRead()
+	defer v.unlockRead()
+	return v.readLocked()
+}
+
+// lockRead acquires the lock needed by readLocked: exclusive if reads
+// mutate state (reset-on-read, delta-on-read), shared otherwise.
+func (v *Value[T]) lockRead() {
+	if v.readMutates() {
 		v.mu.Lock()
-		defer v.mu.Unlock()
+	} else {
+		v.mu.RLock()
+	}
+}
+
+// unlockRead releases the lock acquired by lockRead.
+func (v *Value[T]) unlockRead() {
+	if v.readMutates() {
+		v.mu.Unlock()
+	} else {
+		v.mu.RUnlock()
+	}
+}
+
+// readMutates reports whether Value() changes state, so reads need the
+// exclusive lock.
+func (v *Value[T]) readMutates() bool {
+	return v.resetOnRead || v.delta != nil
+}
 
+// readLocked implements the Value() read, including reset-on-read and
+// delta-on-read. Must be called between lockRead and unlockRead.
+func (v *Value[T]) readLocked() T {
+	if v.share != nil {
+		return v.readShared()
+	}
+	if v.resetOnRead {
 		current := v.current
 		v.current = v.resetValue
 		return current
 	}
+	if v.delta != nil {
+		return v.readDelta()
+	}
+
+	if v.interp != nil {
+		return v.interpolated()
+	}
+	return v.current
+}
+
+// SetCurrent forces the current state to state, bypassing the source and
+// transforms. Intended for tests and fault injection, e.g. to precondition
+// a pipeline before asserting subsequent updates; stateful transforms then
+// continue from state. The AfterUpdate hook runs as for a direct state
+// change, but subscribers are not notified and UpdateCount is unchanged.
+// Like SetInitial, it is not an update: WaitValue keeps waiting for the
+// first update, and Health, the interval histogram and interpolation
+// still reflect only updates from the source.
+// Safe to call before or after Start().
+func (v *Value[T]) SetCurrent(state T) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.storeState(state)
+	v.afterUpdate(state)
+}
 
+// SetCurrentCounted is like SetCurrent but also counts the change in
+// UpdateCount, for tests that precondition a value as if it had been
+// updated. It is still not an update for WaitValue, Health, intervals or
+// interpolation, and subscribers are not notified.
+// Safe to call before or after Start().
+func (v *Value[T]) SetCurrentCounted(state T) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.storeState(state)
+	v.updateCount.Add(1)
+	v.afterUpdate(state)
+}
+
+// Peek returns the current value without side effects.
+// Unlike Value(), it never resets, even if reset-on-read is enabled.
+func (v *Value[T]) Peek() T {
+	if state, ok := v.loadCurrent(); ok {
+		return state
+	}
 	v.mu.RLock()
 	defer v.mu.RUnlock()
 	return v.current
 }
 
+// LastInput returns the most recent source value applied to the pipeline,
+// before transforms. Source values dropped by the gate or coalesced by
+// SetMaxUpdateRate are not reflected.
+func (v *Value[T]) LastInput() T {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.lastInput
+}
+
+// Health reports whether the value was updated within maxStaleness, and
+// when it was last updated. A value that never received an update is not
+// healthy and reports the zero time.
+func (v *Value[T]) Health(maxStaleness time.Duration) (ok bool, lastUpdate time.Time) {
+	v.mu.RLock()
+	lastUpdate = v.lastUpdate
+	v.mu.RUnlock()
+
+	if lastUpdate.IsZero() {
+		return false, lastUpdate
+	}
+	return time.Since(lastUpdate) <= maxStaleness, lastUpdate
+}
+
+// WaitValue blocks until the value has received at least one update
+// or the timeout expires, then returns the current value.
+// The boolean reports whether an update occurred before returning.
+// Reads via Value(), so reset-on-read is honored.
+func (v *Value[T]) WaitValue(timeout time.Duration) (T, bool) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-v.firstUpdate:
+		return v.Value(), true
+	case <-timer.C:
+		return v.Value(), false
+	}
+}
+
+// UpdateRate returns the average number of updates per second since Start().
+// Lock-free. Returns 0 if the value has not been started.
+func (v *Value[T]) UpdateRate() float64 {
+	start := v.startTime.Load()
+	if start == 0 {
+		return 0
+	}
+	elapsed := time.Since(time.Unix(0, start)).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(v.updateCount.Load()) / elapsed
+}
+
+// TransformProfile returns the cumulative time spent in Apply per transform,
+// keyed by transform name. Repeated names are disambiguated with their
+// pipeline position, e.g. "Accumulate#2".
+// Returns nil if profiling is not enabled.
+func (v *Value[T]) TransformProfile() map[string]time.Duration {
+	if !v.profiling {
+		return nil
+	}
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	profile := make(map[string]time.Duration, len(v.transforms))
+	for i, t := range v.transforms {
+		name := t.Name()
+		if _, exists := profile[name]; exists {
+			name = fmt.Sprintf("%s#%d", name, i)
+		}
+		if i < len(v.profileTime) {
+			profile[name] = v.profileTime[i]
+		} else {
+			profile[name] = 0
+		}
+	}
+	return profile
+}
+
 // Stats returns current value metrics without side effects.
 func (v *Value[T]) Stats() ValueStats[T] {
 	v.mu.RLock()
@@ -138,9 +767,37 @@ func (v *Value[T]) Stats() ValueStats[T] {
 		UpdateCount:    v.updateCount.Load(),
 		CurrentValue:   v.current,
 		TransformCount: len(v.transforms),
+		ResetOnRead:    v.resetOnRead,
+		ResetValue:     v.resetValue,
+		CoalescedCount: v.coalescedCount.Load(),
+		QueueDepth:     v.QueueDepth(),
+		DroppedInputs:  v.droppedInputs(),
+
+		MaxUpdateDuration: time.Duration(v.maxUpdateNanos.Load()),
 	}
 }
 
+// String returns a human-readable summary of the value's state,
+// read as a consistent snapshot without side effects.
+// Implements fmt.Stringer.
+func (v *Value[T]) String() string {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	names := make([]string, len(v.transforms))
+	for i, t := range v.transforms {
+		names[i] = t.Name()
+	}
+
+	reset := "off"
+	if v.resetOnRead {
+		reset = fmt.Sprintf("%v", v.resetValue)
+	}
+
+	return fmt.Sprintf("Value{current=%v updates=%d transforms=[%s] resetOnRead=%s}",
+		v.current, v.updateCount.Load(), strings.Join(names, " "), reset)
+}
+
 // GetState returns the current state.
 // Implements transform.State[T].
 // Must be called with lock held (from within run()).
@@ -152,52 +809,235 @@ func (v *Value[T]) GetState() T {
 // Runs in its own goroutine, started by Start().
 func (v *Value[T]) run() {
 	defer close(v.done)
+	defer v.closeSubscribers()
+	defer v.closeTransforms()
+
+	sourceClosed := false
+	defer func() {
+		// Exited early: make sure the source is never blocked on us
+		if !sourceClosed {
+			release(v.source, v.sourceChan)
+		}
+	}()
+	defer v.stopInputBuffer()
+
 	defer func() {
 		if r := recover(); r != nil {
+			// Hook panics in HookPanicPropagate mode must fail loudly
+			if hp, ok := r.(hookPanic); ok {
+				panic(hp)
+			}
 			// Transform panicked - isolate error, don't crash program
-			// Future: could call panic hook here for observability
 		}
 	}()
 
-	for sourceValue := range v.sourceChan {
-		v.mu.Lock()
+	// Rate-limited values coalesce inputs, applying the latest once per interval
+	var th *throttle[T]
+	if v.maxUpdateInterval > 0 {
+		th = &throttle[T]{interval: v.maxUpdateInterval}
+	}
 
-		hook := v.getUpdateHook()
+	for {
+		select {
+		case sourceValue, ok := <-v.inputChan:
+			if !ok {
+				sourceClosed = true
+				v.flush(th)
+				return
+			}
+			v.receive(sourceValue, th)
+		case <-th.ready():
+			v.flush(th)
+		case <-v.stop:
+			if v.drainOnStop.Load() {
+				sourceClosed = v.drain(th)
+			}
+			return
+		}
+	}
+}
 
-		// Notify: input received
-		if hook != nil {
-			v.safeHookCall(func() { hook.OnInput(sourceValue, v.current) })
+// receive handles one source value, applying it now or holding it in th.
+func (v *Value[T]) receive(sourceValue T, th *throttle[T]) {
+	if th == nil {
+		v.apply(sourceValue)
+		return
+	}
+
+	applyNow, coalesced := th.offer(sourceValue, time.Now())
+	if coalesced {
+		v.coalescedCount.Add(1)
+	}
+	if applyNow {
+		v.apply(sourceValue)
+	}
+}
+
+// flush applies the input pending in th, if any.
+func (v *Value[T]) flush(th *throttle[T]) {
+	if pending, ok := th.take(time.Now()); ok {
+		v.apply(pending)
+	}
+}
+
+// drain processes source values that are already available without
+// waiting for new ones, then flushes any pending input.
+// Returns true if the source channel was closed.
+func (v *Value[T]) drain(th *throttle[T]) bool {
+	defer v.flush(th)
+
+	for {
+		select {
+		case sourceValue, ok := <-v.drainChan():
+			if !ok {
+				return true
+			}
+			v.receive(sourceValue, th)
+		default:
+			return false
 		}
+	}
+}
+
+// drainChan returns the channel drain reads from: the update goroutine's
+// input, or the source channel for synchronous values.
+func (v *Value[T]) drainChan() <-chan T {
+	if v.inputChan != nil {
+		return v.inputChan
+	}
+	return v.sourceChan
+}
+
+// discard receives and drops values until ch is closed.
+func discard[T any](ch <-chan T) {
+	for range ch {
+	}
+}
+
+// apply updates the state from sourceValue and publishes the result.
+func (v *Value[T]) apply(sourceValue T) {
+	start := time.Now()
+	defer v.recordUpdateDuration(start)
+
+	newState, seq, ok := v.update(sourceValue)
+	if !ok {
+		return
+	}
 
-		// Apply transforms with notifications
-		transformed := sourceValue
-		for _, t := range v.transforms {
-			input := transformed
-			currentState := v.current
+	if v.onFirstUpdate != nil && !v.firstFired {
+		v.firstFired = true
+		v.safeHookCall(func() { v.onFirstUpdate(newState) })
+	}
+	v.evaluateAlarms(newState)
 
+	v.publish(newState, seq)
+}
+
+// recordUpdateDuration raises MaxUpdateDuration if the update that began
+// at start took longer than any before. Only called from run().
+func (v *Value[T]) recordUpdateDuration(start time.Time) {
+	if d := int64(time.Since(start)); d > v.maxUpdateNanos.Load() {
+		v.maxUpdateNanos.Store(d)
+	}
+}
+
+// update runs sourceValue through the pipeline and stores the result.
+// Returns the new state, or false if the input was dropped by the gate.
+func (v *Value[T]) update(sourceValue T) (T, uint64, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.gate != nil && !v.gate() {
+		var zero T
+		return zero, 0, false
+	}
+
+	v.lastInput = sourceValue
+	hook := v.getUpdateHook()
+
+	// Notify: input received
+	if hook != nil {
+		v.safeHookCall(func() { hook.OnInput(sourceValue, v.current) })
+	}
+
+	// Apply transforms with notifications
+	transformed := sourceValue
+	for i, t := range v.transforms {
+		input := transformed
+		currentState := v.current
+
+		if v.profiling {
+			start := time.Now()
+			transformed = t.Apply(transformed, v)
+			v.recordApply(i, time.Since(start))
+		} else {
 			transformed = t.Apply(transformed, v)
+		}
 
-			if hook != nil {
-				name := t.Name()
-				v.safeHookCall(func() {
-					hook.OnTransform(name, input, transformed, currentState)
-				})
-			}
+		if hook != nil {
+			name := t.Name()
+			v.safeHookCall(func() {
+				hook.OnTransform(name, input, transformed, currentState)
+			})
 		}
+	}
 
-		// Update state
-		v.setState(transformed)
-		v.updateCount.Add(1)
+	// Update state
+	v.setState(transformed)
+	v.recordHistory(transformed)
+	seq := v.updateCount.Add(1)
 
-		v.mu.Unlock()
+	return transformed, seq, true
+}
+
+// publish sends newState, the state after the seq-th update, to all
+// subscribers. Must be called without v.mu held.
+func (v *Value[T]) publish(newState T, seq uint64) {
+	broadcast.PublishSeq(&v.broadcaster, newState, seq, v.stop)
+}
+
+// closeSubscribers closes all subscriber channels.
+// Later Subscribe calls return an already closed channel.
+func (v *Value[T]) closeSubscribers() {
+	broadcast.Close(&v.broadcaster)
+}
+
+// recordApply adds d to the profile of the transform at position i.
+// Must be called with v.mu held (locked).
+func (v *Value[T]) recordApply(i int, d time.Duration) {
+	if v.profileTime == nil {
+		v.profileTime = make([]time.Duration, len(v.transforms))
 	}
+	v.profileTime[i] += d
 }
 
-// setState updates the internal state and triggers AfterUpdate hook.
+// setState records an update to newState: it stores the state, tracks
+// update timing, signals the first update and triggers AfterUpdate hook.
 // Must be called with v.mu held (locked).
 func (v *Value[T]) setState(newState T) {
+	now := time.Now()
+	if v.intervals != nil && !v.lastUpdate.IsZero() {
+		v.intervals.observe(now.Sub(v.lastUpdate))
+	}
+	v.storeState(newState)
+	v.lastUpdate = now
+	if v.interp != nil {
+		v.interp.observe(any(newState).(float64), now)
+	}
+	v.firstOnce.Do(func() { close(v.firstUpdate) })
+
+	v.afterUpdate(newState)
+}
+
+// storeState replaces the current state, without recording an update.
+// Must be called with v.mu held (locked).
+func (v *Value[T]) storeState(newState T) {
 	v.current = newState
+	v.publishCurrent(newState)
+}
 
+// afterUpdate triggers AfterUpdate hook for a state change.
+func (v *Value[T]) afterUpdate(newState T) {
 	if hook := v.getUpdateHook(); hook != nil {
 		v.safeHookCall(func() { hook.AfterUpdate(newState) })
 	}
@@ -212,10 +1052,11 @@ func (v *Value[T]) getUpdateHook() UpdateHook[T] {
 }
 
 // safeHookCall executes hook synchronously with panic recovery.
+// A panic is handled according to the hook panic mode.
 func (v *Value[T]) safeHookCall(fn func()) {
 	defer func() {
 		if r := recover(); r != nil {
-			// Hook panicked, silently ignore
+			v.handleHookPanic(r)
 		}
 	}()
 	fn()
@@ -1,6 +1,7 @@
 package value_test
 
 import (
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -101,6 +102,88 @@ func BenchmarkValue_WithoutReset(b *testing.B) {
 	}
 }
 
+// BenchmarkValue_LockFreeReads measures reads via EnableLockFreeReads,
+// for comparison with BenchmarkValue_WithoutReset.
+func BenchmarkValue_LockFreeReads(b *testing.B) {
+	clk := clock.NewPeriodicClock(1 * time.Millisecond)
+	src := source.NewConstSource(clk, 1)
+
+	val := value.New(src).
+		AddTransform(transform.NewAccumulate[int]()).
+		EnableLockFreeReads().
+		Start()
+	defer val.Stop()
+
+	clk.Start()
+	defer clk.Stop()
+
+	b.ResetTimer()
+
+	for b.Loop() {
+		_ = val.Value()
+	}
+}
+
+// BenchmarkValue_ConcurrentReads compares concurrent reads through the
+// RWMutex and lock-free paths.
+func BenchmarkValue_ConcurrentReads(b *testing.B) {
+	for _, lockFree := range []bool{false, true} {
+		name := "RWMutex"
+		if lockFree {
+			name = "LockFree"
+		}
+		b.Run(name, func(b *testing.B) {
+			clk := clock.NewPeriodicClock(1 * time.Millisecond)
+			src := source.NewConstSource(clk, 1)
+
+			val := value.New(src).AddTransform(transform.NewAccumulate[int]())
+			if lockFree {
+				val.EnableLockFreeReads()
+			}
+			val.Start()
+			defer val.Stop()
+
+			clk.Start()
+			defer clk.Stop()
+
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					_ = val.Value()
+				}
+			})
+		})
+	}
+}
+
+// BenchmarkScheduler_Dispatch measures one scheduled update as the number
+// of values sharing the scheduler grows. Only the select over idle sources
+// scales with it.
+func BenchmarkScheduler_Dispatch(b *testing.B) {
+	for _, n := range []int{10, 100, 1000} {
+		b.Run(fmt.Sprintf("values=%d", n), func(b *testing.B) {
+			sched := value.NewScheduler(4)
+			defer sched.Stop()
+
+			pubs := make([]chanPublisher[int], n)
+			for i := range pubs {
+				pubs[i] = chanPublisher[int]{ch: make(chan int)}
+				value.New[int](pubs[i]).
+					AddTransform(transform.NewAccumulate[int]()).
+					SetScheduler(sched).
+					Start()
+			}
+
+			b.ResetTimer()
+			i := 0
+			for b.Loop() {
+				pubs[i%n].ch <- 1
+				i++
+			}
+		})
+	}
+}
+
 // ============================================================================
 // STRESS TESTS
 // Extreme scenarios to expose race conditions and verify robustness
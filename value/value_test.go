@@ -0,0 +1,1466 @@
+package value_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/neox5/simv/clock"
+	"github.com/neox5/simv/simvtest"
+	"github.com/neox5/simv/source"
+	"github.com/neox5/simv/transform"
+	"github.com/neox5/simv/value"
+)
+
+// ============================================================================
+// FUNCTIONAL TESTS
+// Verify behavior of individual Value features
+// ============================================================================
+
+// identity passes its input through unchanged.
+type identity[T any] struct{}
+
+func (identity[T]) Apply(incoming T, _ transform.State[T]) T { return incoming }
+func (identity[T]) Name() string                             { return "Identity" }
+
+// chanPublisher publishes values sent on ch, giving tests exact control
+// over emissions.
+type chanPublisher[T any] struct {
+	ch chan T
+}
+
+func (p chanPublisher[T]) Subscribe() <-chan T { return p.ch }
+
+// manualClock ticks once per send on its channel.
+type manualClock chan struct{}
+
+func (c manualClock) Subscribe() <-chan struct{} { return c }
+func (c manualClock) Start()                     {}
+func (c manualClock) Stop()                      {}
+func (c manualClock) Stats() clock.ClockStats    { return clock.ClockStats{} }
+
+// waitFor polls cond until it holds, failing the test after a second.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// TestConstSource_String verifies string constants flow through a Value.
+func TestConstSource_String(t *testing.T) {
+	clk := clock.NewPeriodicClock(1 * time.Millisecond)
+	src := source.NewConstSource(clk, "hello")
+
+	val := value.New(src).
+		AddTransform(identity[string]{}).
+		Start()
+	defer val.Stop()
+
+	clk.Start()
+	defer clk.Stop()
+
+	got, ok := val.WaitValue(time.Second)
+	if !ok {
+		t.Fatal("no update received within timeout")
+	}
+	if got != "hello" {
+		t.Errorf("got %q, want %q", got, "hello")
+	}
+}
+
+// TestConstSource_Struct verifies struct constants flow through a Value.
+func TestConstSource_Struct(t *testing.T) {
+	type point struct {
+		X, Y float64
+		Tag  string
+	}
+	want := point{X: 1.5, Y: -2, Tag: "p"}
+
+	clk := clock.NewPeriodicClock(1 * time.Millisecond)
+	src := source.NewConstSource(clk, want)
+
+	val := value.New(src).
+		AddTransform(identity[point]{}).
+		Start()
+	defer val.Stop()
+
+	clk.Start()
+	defer clk.Stop()
+
+	got, ok := val.WaitValue(time.Second)
+	if !ok {
+		t.Fatal("no update received within timeout")
+	}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+// TestStructPipeline_EndToEnd verifies a struct-typed value supports the
+// full API, including transforms, reset-on-read, subscriptions and Stats,
+// without numeric assumptions.
+func TestStructPipeline_EndToEnd(t *testing.T) {
+	type vec struct{ X, Y float64 }
+	add := func(a, b vec) vec { return vec{a.X + b.X, a.Y + b.Y} }
+
+	clk := clock.NewPeriodicClock(1 * time.Millisecond)
+	src := source.NewConstSource(clk, vec{1, -1})
+
+	val := value.New(src).
+		AddTransform(transform.NewFieldAccumulate(add)).
+		SetInitial(vec{10, 10}).
+		EnableResetOnRead(vec{}).
+		SetSynchronous().
+		Start()
+	defer val.Stop()
+
+	clk.Start()
+	defer clk.Stop()
+
+	val.Step()
+	val.Step()
+
+	stats := val.Stats()
+	if want := (vec{12, 8}); stats.CurrentValue != want || stats.UpdateCount != 2 {
+		t.Errorf("Stats: got current=%+v updates=%d, want %+v and 2",
+			stats.CurrentValue, stats.UpdateCount, want)
+	}
+	if stats.ResetValue != (vec{}) || !stats.ResetOnRead {
+		t.Errorf("Stats: got reset=%v resetValue=%+v, want true and zero", stats.ResetOnRead, stats.ResetValue)
+	}
+	if got := val.Value(); got != (vec{12, 8}) {
+		t.Errorf("Value: got %+v, want {12 8}", got)
+	}
+	if got := val.Peek(); got != (vec{}) {
+		t.Errorf("after reset: got %+v, want zero", got)
+	}
+	if got := value.SnapshotMany(val)[0]; got != (vec{}) {
+		t.Errorf("SnapshotMany: got %+v, want zero", got)
+	}
+	if got := val.String(); got == "" {
+		t.Error("String: got empty string")
+	}
+}
+
+// TestSubscribe_Sequence verifies subscribers receive every update in order.
+func TestSubscribe_Sequence(t *testing.T) {
+	clk := clock.NewPeriodicClock(1 * time.Millisecond)
+	src := source.NewConstSource(clk, 1)
+
+	val := value.New(src).
+		AddTransform(transform.NewAccumulate[int]()).
+		Start()
+	defer val.Stop()
+
+	got := make(chan []int)
+	go func() { got <- simvtest.CollectN(val, 5, time.Second) }()
+
+	// Give the collector time to subscribe before the first tick
+	time.Sleep(10 * time.Millisecond)
+	clk.Start()
+	defer clk.Stop()
+
+	simvtest.AssertSequence(t, <-got, []int{1, 2, 3, 4, 5})
+}
+
+// failingWriter fails every write.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+// TestWriterSink_RecordsUpdates verifies each update becomes one formatted
+// line, flushed when the value stops, and a write error is reported.
+func TestWriterSink_RecordsUpdates(t *testing.T) {
+	pub := chanPublisher[int]{ch: make(chan int, 3)}
+	val := value.New[int](pub).
+		AddTransform(transform.NewAccumulate[int]()).
+		SetSynchronous().
+		Start()
+
+	var out strings.Builder
+	sink := value.NewWriterSink(val, &out, func(v int) string { return fmt.Sprintf("total=%d", v) })
+	failed := value.NewWriterSink(val, failingWriter{}, strconv.Itoa)
+
+	for _, in := range []int{1, 2, 3} {
+		pub.ch <- in
+		val.Step()
+	}
+	val.Stop()
+
+	if err := sink.Wait(); err != nil {
+		t.Fatalf("Wait: got %v, want nil", err)
+	}
+	if got, want := out.String(), "total=1\ntotal=3\ntotal=6\n"; got != want {
+		t.Errorf("output: got %q, want %q", got, want)
+	}
+	if err := failed.Wait(); err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Errorf("failing writer: got %v, want the write error", err)
+	}
+}
+
+// TestSetGate_DropsWhileClosed verifies source values arriving while the
+// gate is closed are neither applied nor counted.
+func TestSetGate_DropsWhileClosed(t *testing.T) {
+	var open atomic.Bool
+	open.Store(true)
+
+	pub := chanPublisher[int]{ch: make(chan int, 1)}
+	val := value.New[int](pub).
+		AddTransform(transform.NewAccumulate[int]()).
+		SetGate(open.Load).
+		SetSynchronous().
+		Start()
+	defer val.Stop()
+
+	for _, step := range []struct {
+		open bool
+		in   int
+	}{{true, 1}, {false, 10}, {false, 20}, {true, 2}} {
+		open.Store(step.open)
+		pub.ch <- step.in
+		val.Step()
+	}
+	if got := val.Stats(); got.CurrentValue != 3 || got.UpdateCount != 2 {
+		t.Errorf("got current=%d updates=%d, want 3 and 2", got.CurrentValue, got.UpdateCount)
+	}
+}
+
+// TestSetMaxUpdateRate_Coalesces verifies a burst within one interval is
+// applied once, as its latest value, and the replaced values are counted.
+func TestSetMaxUpdateRate_Coalesces(t *testing.T) {
+	pub := chanPublisher[int]{ch: make(chan int)}
+	val := value.New[int](pub).
+		SetMaxUpdateRate(time.Hour).
+		Start()
+	defer val.Stop()
+
+	pub.ch <- 1 // applied immediately, opening the interval
+	waitFor(t, "first update", func() bool { return val.Stats().UpdateCount == 1 })
+	for i := 2; i <= 5; i++ {
+		pub.ch <- i // 2 is held, 3-5 each replace the held value
+	}
+	waitFor(t, "coalescing", func() bool { return val.Stats().CoalescedCount == 3 })
+	if got := val.Stats(); got.UpdateCount != 1 || got.CurrentValue != 1 {
+		t.Errorf("within interval: got current=%d updates=%d, want 1 and 1",
+			got.CurrentValue, got.UpdateCount)
+	}
+
+	val.StopAndDrain() // applies the held value
+	if got := val.Stats(); got.UpdateCount != 2 || got.CurrentValue != 5 {
+		t.Errorf("after drain: got current=%d updates=%d, want 5 and 2",
+			got.CurrentValue, got.UpdateCount)
+	}
+}
+
+// TestAggregate_SumMaxMin verifies each tick publishes the reduction of the
+// inputs' current values without resetting them.
+func TestAggregate_SumMaxMin(t *testing.T) {
+	var inputs []*value.Value[int]
+	for _, in := range []int{4, -2, 7} {
+		pub := chanPublisher[int]{ch: make(chan int, 1)}
+		v := value.New[int](pub).EnableResetOnRead(0).SetSynchronous().Start()
+		defer v.Stop()
+		pub.ch <- in
+		v.Step()
+		inputs = append(inputs, v)
+	}
+
+	clk := make(manualClock, 1)
+	total := value.Aggregate(clk, inputs, value.Sum[int]).SetSynchronous().Start()
+	defer total.Stop()
+
+	clk <- struct{}{}
+	total.Step()
+	if got := total.Peek(); got != 9 {
+		t.Errorf("Sum: got %d, want 9", got)
+	}
+	if got := inputs[0].Peek(); got != 4 {
+		t.Errorf("input after sampling: got %d, want 4 not reset", got)
+	}
+
+	samples := []int{4, -2, 7}
+	if got := value.Max(samples); got != 7 {
+		t.Errorf("Max: got %d, want 7", got)
+	}
+	if got := value.Min(samples); got != -2 {
+		t.Errorf("Min: got %d, want -2", got)
+	}
+	if got := value.Max([]int{-3, -1}); got != -1 {
+		t.Errorf("Max of negatives: got %d, want -1", got)
+	}
+	if got := value.Min[int](nil); got != 0 {
+		t.Errorf("Min of none: got %d, want 0", got)
+	}
+}
+
+// TestUpdateRate_SinceStart verifies the rate is 0 before Start and then
+// averages updates over the time since Start.
+func TestUpdateRate_SinceStart(t *testing.T) {
+	pub := chanPublisher[int]{ch: make(chan int, 10)}
+	val := value.New[int](pub).SetSynchronous()
+	if got := val.UpdateRate(); got != 0 {
+		t.Errorf("before Start: got %v, want 0", got)
+	}
+
+	begin := time.Now()
+	val.Start()
+	defer val.Stop()
+	time.Sleep(20 * time.Millisecond)
+	for i := range 10 {
+		pub.ch <- i
+		val.Step()
+	}
+
+	rate := val.UpdateRate()
+	if lower := 10 / time.Since(begin).Seconds(); rate < lower || rate > 10/0.02 {
+		t.Errorf("got %.1f updates/s, want between %.1f and 500", rate, lower)
+	}
+}
+
+// TestOnFirstUpdate_FiresOnce verifies the callback runs once, with the
+// state after the first real update rather than the baseline.
+func TestOnFirstUpdate_FiresOnce(t *testing.T) {
+	var calls []int
+	pub := chanPublisher[int]{ch: make(chan int, 3)}
+	val := value.New[int](pub).
+		AddTransform(transform.NewAccumulate[int]()).
+		SetInitial(5).
+		OnFirstUpdate(func(v int) { calls = append(calls, v) }).
+		SetSynchronous().
+		Start()
+	defer val.Stop()
+
+	if len(calls) != 0 {
+		t.Fatalf("before any update: got calls %v, want none", calls)
+	}
+	for _, in := range []int{2, 3, 4} {
+		pub.ch <- in
+		val.Step()
+	}
+	if len(calls) != 1 || calls[0] != 7 {
+		t.Errorf("got calls %v, want [7]", calls)
+	}
+}
+
+// sleepy sleeps for d in every Apply, passing its input through.
+type sleepy struct{ d time.Duration }
+
+func (s sleepy) Apply(incoming int, _ transform.State[int]) int {
+	time.Sleep(s.d)
+	return incoming
+}
+func (sleepy) Name() string { return "Sleepy" }
+
+// TestTransformProfile_PerTransform verifies Apply time is attributed per
+// transform, repeated names get their position, and profiling is opt-in.
+func TestTransformProfile_PerTransform(t *testing.T) {
+	pub := chanPublisher[int]{ch: make(chan int, 3)}
+	build := func() *value.Value[int] {
+		return value.New[int](pub).
+			AddTransform(identity[int]{}).
+			AddTransform(sleepy{2 * time.Millisecond}).
+			AddTransform(identity[int]{}).
+			SetSynchronous()
+	}
+	if got := build().TransformProfile(); got != nil {
+		t.Errorf("disabled: got %v, want nil", got)
+	}
+
+	val := build().EnableTransformProfiling().Start()
+	defer val.Stop()
+	for i := range 3 {
+		pub.ch <- i
+		val.Step()
+	}
+
+	profile := val.TransformProfile()
+	if len(profile) != 3 {
+		t.Fatalf("got %v, want Identity, Sleepy and Identity#2", profile)
+	}
+	if got := profile["Sleepy"]; got < 6*time.Millisecond {
+		t.Errorf("Sleepy: got %v, want at least 6ms for 3 updates", got)
+	}
+	if slow := profile["Sleepy"]; profile["Identity"] >= slow || profile["Identity#2"] >= slow {
+		t.Errorf("got %v, want the identities far below Sleepy", profile)
+	}
+}
+
+// TestEnableInterpolation_Extrapolates verifies Value() follows the slope
+// of the last two updates while Peek() reports the actual state.
+func TestEnableInterpolation_Extrapolates(t *testing.T) {
+	pub := chanPublisher[float64]{ch: make(chan float64, 2)}
+	val := value.New[float64](pub).EnableInterpolation().SetSynchronous().Start()
+	defer val.Stop()
+
+	pub.ch <- 10
+	val.Step()
+	if got := val.Value(); got != 10 {
+		t.Errorf("one update: got %v, want 10 as is", got)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	pub.ch <- 20
+	val.Step()
+	time.Sleep(20 * time.Millisecond)
+
+	if got := val.Value(); got <= 20 {
+		t.Errorf("rising: got %v, want an estimate above the last update 20", got)
+	}
+	if got := val.Peek(); got != 20 {
+		t.Errorf("Peek: got %v, want 20", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Value[int]: expected panic")
+		}
+	}()
+	value.New[int](chanPublisher[int]{ch: make(chan int)}).EnableInterpolation()
+}
+
+// TestMaxUpdateDuration_IncludesDelivery verifies the longest update
+// covers transforms and the wait for a slow subscriber.
+func TestMaxUpdateDuration_IncludesDelivery(t *testing.T) {
+	pub := chanPublisher[int]{ch: make(chan int)}
+	val := value.New[int](pub).AddTransform(sleepy{2 * time.Millisecond})
+	updates := val.Subscribe()
+	val.Start()
+	defer val.Stop()
+
+	if got := val.Stats().MaxUpdateDuration; got != 0 {
+		t.Errorf("before any update: got %v, want 0", got)
+	}
+
+	pub.ch <- 1
+	time.Sleep(20 * time.Millisecond) // the update waits for this reader
+	<-updates
+	waitFor(t, "slow update", func() bool { return val.Stats().MaxUpdateDuration >= 20*time.Millisecond })
+
+	pub.ch <- 2
+	<-updates
+	if got := val.Stats().MaxUpdateDuration; got < 20*time.Millisecond {
+		t.Errorf("after a fast update: got %v, want the earlier maximum kept", got)
+	}
+}
+
+// TestLastInput_BeforeTransforms verifies LastInput reports the raw source
+// value of the latest applied update, ignoring gated values.
+func TestLastInput_BeforeTransforms(t *testing.T) {
+	var open atomic.Bool
+	open.Store(true)
+
+	pub := chanPublisher[int]{ch: make(chan int, 1)}
+	val := value.New[int](pub).
+		AddTransform(transform.NewAccumulate[int]()).
+		SetGate(open.Load).
+		SetSynchronous().
+		Start()
+	defer val.Stop()
+
+	pub.ch <- 4
+	val.Step()
+	pub.ch <- 3
+	val.Step()
+	if got := val.LastInput(); got != 3 {
+		t.Errorf("got %d, want raw input 3 (state is %d)", got, val.Peek())
+	}
+
+	open.Store(false)
+	pub.ch <- 9
+	val.Step()
+	if got := val.LastInput(); got != 3 {
+		t.Errorf("after a gated input: got %d, want 3", got)
+	}
+}
+
+// hookClock runs start and stop in its Start and Stop.
+type hookClock struct {
+	manualClock
+	start, stop func()
+}
+
+func (c hookClock) Start() { c.start() }
+func (c hookClock) Stop()  { c.stop() }
+
+// TestGroup_StartStopOrder verifies values start before the clock added
+// after them, and the clock stops first, once.
+func TestGroup_StartStopOrder(t *testing.T) {
+	a := value.New[int](chanPublisher[int]{ch: make(chan int)})
+	b := value.New[int](chanPublisher[int]{ch: make(chan int)})
+
+	var startedFirst, runningAtStop bool
+	stops := 0
+	clk := hookClock{
+		manualClock: make(manualClock),
+		start: func() {
+			startedFirst = errors.Is(a.TryStart(), value.ErrAlreadyStarted) &&
+				errors.Is(b.TryStart(), value.ErrAlreadyStarted)
+		},
+		stop: func() {
+			stops++
+			select {
+			case <-a.Done():
+			default:
+				runningAtStop = true
+			}
+		},
+	}
+
+	g := value.NewGroup().Add(a).Add(b).AddClock(clk)
+	g.StartAll()
+	if !startedFirst {
+		t.Error("clock started before its values")
+	}
+
+	g.StopAll()
+	g.StopAll()
+	if !runningAtStop {
+		t.Error("values stopped before the clock")
+	}
+	if stops != 1 {
+		t.Errorf("clock stopped %d times, want 1", stops)
+	}
+	for name, v := range map[string]*value.Value[int]{"a": a, "b": b} {
+		select {
+		case <-v.Done():
+		default:
+			t.Errorf("%s still running after StopAll", name)
+		}
+	}
+}
+
+// TestDerive_RunningView verifies a derived value applies its transform to
+// each update of a running value, leaves it unchanged, and finishes with it.
+func TestDerive_RunningView(t *testing.T) {
+	pub := chanPublisher[int]{ch: make(chan int, 3)}
+	base := value.New[int](pub).SetSynchronous().Start()
+	total := base.Derive(transform.NewAccumulate[int]())
+	defer total.Stop()
+
+	for _, in := range []int{1, 2, 3} {
+		pub.ch <- in
+		base.Step()
+	}
+	waitFor(t, "derived updates", func() bool { return total.Stats().UpdateCount == 3 })
+	if got := total.Peek(); got != 6 {
+		t.Errorf("derived: got %d, want running total 6", got)
+	}
+	if got := base.Peek(); got != 3 {
+		t.Errorf("base: got %d, want its own state 3", got)
+	}
+
+	base.Stop()
+	select {
+	case <-total.Done():
+	case <-time.After(time.Second):
+		t.Error("derived value still running after base stopped")
+	}
+}
+
+// TestStartContext_StopsOnCancel verifies cancelling the context stops
+// the value, keeping the state reached so far.
+func TestStartContext_StopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	pub := chanPublisher[int]{ch: make(chan int)}
+	val := value.New[int](pub).
+		AddTransform(transform.NewAccumulate[int]()).
+		StartContext(ctx)
+	defer val.Stop()
+
+	pub.ch <- 2
+	pub.ch <- 3
+	waitFor(t, "updates", func() bool { return val.Stats().UpdateCount == 2 })
+
+	cancel()
+	select {
+	case <-val.Done():
+	case <-time.After(time.Second):
+		t.Fatal("value still running after cancel")
+	}
+	if got := val.Peek(); got != 5 {
+		t.Errorf("after cancel: got %d, want 5", got)
+	}
+}
+
+// TestIntervalHistogram_Buckets verifies intervals between updates land
+// in their buckets, skipping the first update.
+func TestIntervalHistogram_Buckets(t *testing.T) {
+	pub := chanPublisher[int]{ch: make(chan int, 3)}
+	val := value.New[int](pub).
+		EnableIntervalHistogram([]time.Duration{10 * time.Millisecond, time.Minute}).
+		SetSynchronous().
+		Start()
+	defer val.Stop()
+
+	step := func() {
+		pub.ch <- 1
+		val.Step()
+	}
+	step()
+	step() // well under 10ms
+	time.Sleep(30 * time.Millisecond)
+	step()
+
+	h := val.IntervalHistogram()
+	if want := []uint64{1, 1, 0}; !slices.Equal(h.Counts, want) {
+		t.Errorf("Counts: got %v, want %v", h.Counts, want)
+	}
+	if h.Count != 2 || h.Sum < 30*time.Millisecond {
+		t.Errorf("got count=%d sum=%v, want 2 and at least 30ms", h.Count, h.Sum)
+	}
+	if got := value.New[int](pub).IntervalHistogram(); got.Count != 0 || got.Buckets != nil {
+		t.Errorf("disabled: got %+v, want zero", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("unsorted buckets: expected panic")
+		}
+	}()
+	value.New[int](pub).EnableIntervalHistogram([]time.Duration{time.Second, time.Second})
+}
+
+// TestBundle_Snapshot verifies channels are read together by name, with
+// reset-on-read applied, and the bundle stops every channel.
+func TestBundle_Snapshot(t *testing.T) {
+	temp := chanPublisher[float64]{ch: make(chan float64)}
+	hits := chanPublisher[int]{ch: make(chan int)}
+
+	b := value.NewBundle(make(manualClock))
+	tv := value.AddChannel[float64](b, "temp", temp)
+	hv := value.AddChannel[int](b, "hits", hits, transform.NewAccumulate[int]()).EnableResetOnRead(0)
+	b.Start()
+	defer b.Stop()
+
+	temp.ch <- 21.5
+	hits.ch <- 2
+	hits.ch <- 3
+	waitFor(t, "updates", func() bool {
+		return tv.Stats().UpdateCount == 1 && hv.Stats().UpdateCount == 2
+	})
+
+	if got := b.Names(); !slices.Equal(got, []string{"temp", "hits"}) {
+		t.Errorf("Names: got %v, want insertion order", got)
+	}
+	if got := b.Snapshot(); got["temp"] != 21.5 || got["hits"] != 5 {
+		t.Errorf("first snapshot: got %v, want temp 21.5 and hits 5", got)
+	}
+	if got := b.Snapshot(); got["temp"] != 21.5 || got["hits"] != 0 {
+		t.Errorf("second snapshot: got %v, want hits reset to 0", got)
+	}
+
+	b.Stop()
+	for name, done := range map[string]<-chan struct{}{"temp": tv.Done(), "hits": hv.Done()} {
+		select {
+		case <-done:
+		default:
+			t.Errorf("%s still running after Stop", name)
+		}
+	}
+}
+
+// TestStep_ResetOnRead verifies synchronous stepping processes exactly one
+// source value per Step and preserves reset-on-read semantics.
+func TestStep_ResetOnRead(t *testing.T) {
+	clk := clock.NewPeriodicClock(1 * time.Millisecond)
+	src := source.NewConstSource(clk, 1)
+
+	val := value.New(src).
+		AddTransform(transform.NewAccumulate[int]()).
+		EnableResetOnRead(0).
+		SetSynchronous().
+		Start()
+	defer val.Stop()
+
+	clk.Start()
+	defer clk.Stop()
+
+	for range 3 {
+		if !val.Step() {
+			t.Fatal("Step returned false while source is open")
+		}
+	}
+	if got := val.Value(); got != 3 {
+		t.Errorf("after 3 steps: got %d, want 3", got)
+	}
+	if got := val.Value(); got != 0 {
+		t.Errorf("second read: got %d, want reset value 0", got)
+	}
+
+	val.Step()
+	if got := val.Stats(); got.UpdateCount != 4 || got.CurrentValue != 1 {
+		t.Errorf("after 4th step: got updates=%d current=%d, want 4 and 1",
+			got.UpdateCount, got.CurrentValue)
+	}
+
+	val.Stop()
+	if val.Step() {
+		t.Error("Step returned true after Stop")
+	}
+}
+
+// TestSetCurrent_Precondition verifies stateful transforms continue from an
+// injected state without it counting as an update.
+func TestSetCurrent_Precondition(t *testing.T) {
+	clk := clock.NewPeriodicClock(1 * time.Millisecond)
+	src := source.NewConstSource(clk, 1)
+
+	val := value.New(src).
+		AddTransform(transform.NewAccumulate[int]()).
+		SetSynchronous().
+		Start()
+	defer val.Stop()
+
+	clk.Start()
+	defer clk.Stop()
+
+	val.SetCurrent(100)
+	val.Step()
+
+	if got := val.Stats(); got.UpdateCount != 1 || got.CurrentValue != 101 {
+		t.Errorf("got updates=%d current=%d, want 1 and 101",
+			got.UpdateCount, got.CurrentValue)
+	}
+}
+
+// TestSetCurrent_NotAnUpdate verifies injected states do not count as an
+// update for WaitValue and Health, and SetCurrentCounted only bumps
+// UpdateCount.
+func TestSetCurrent_NotAnUpdate(t *testing.T) {
+	val := value.New[int](chanPublisher[int]{ch: make(chan int)}).Start()
+	defer val.Stop()
+
+	val.SetCurrent(5)
+	if got, ok := val.WaitValue(10 * time.Millisecond); ok || got != 5 {
+		t.Errorf("WaitValue: got %d, %v, want 5 without an update", got, ok)
+	}
+	if ok, last := val.Health(time.Hour); ok || !last.IsZero() {
+		t.Errorf("Health: got %v at %v, want unhealthy and never updated", ok, last)
+	}
+
+	val.SetCurrentCounted(7)
+	if got := val.Stats(); got.UpdateCount != 1 || got.CurrentValue != 7 {
+		t.Errorf("Stats: got updates=%d current=%d, want 1 and 7",
+			got.UpdateCount, got.CurrentValue)
+	}
+	if _, ok := val.WaitValue(10 * time.Millisecond); ok {
+		t.Error("WaitValue: SetCurrentCounted reported as an update")
+	}
+	if ok, _ := val.Health(time.Hour); ok {
+		t.Error("Health: SetCurrentCounted reported as an update")
+	}
+}
+
+// TestSetInitial_Baseline verifies the baseline is visible before any tick
+// and is returned once under reset-on-read.
+func TestSetInitial_Baseline(t *testing.T) {
+	clk := clock.NewPeriodicClock(1 * time.Millisecond)
+	src := source.NewConstSource(clk, 1)
+
+	val := value.New(src).
+		SetInitial(20).
+		EnableResetOnRead(0).
+		Start()
+	defer val.Stop()
+
+	if got := val.Stats(); got.CurrentValue != 20 || got.UpdateCount != 0 {
+		t.Errorf("before tick: got current=%d updates=%d, want 20 and 0",
+			got.CurrentValue, got.UpdateCount)
+	}
+	if got := val.Value(); got != 20 {
+		t.Errorf("first read: got %d, want baseline 20", got)
+	}
+	if got := val.Value(); got != 0 {
+		t.Errorf("second read: got %d, want reset value 0", got)
+	}
+}
+
+// TestSetResetValue_AfterStart verifies the reset baseline can be changed
+// while the value is running.
+func TestSetResetValue_AfterStart(t *testing.T) {
+	clk := clock.NewPeriodicClock(1 * time.Millisecond)
+	src := source.NewConstSource(clk, 1)
+
+	val := value.New(src).
+		SetInitial(20).
+		EnableResetOnRead(0).
+		Start()
+	defer val.Stop()
+
+	val.SetResetValue(7)
+	if got := val.Stats().ResetValue; got != 7 {
+		t.Errorf("Stats: got reset value %d, want 7", got)
+	}
+	if got := val.Value(); got != 20 {
+		t.Errorf("first read: got %d, want unchanged state 20", got)
+	}
+	if got := val.Value(); got != 7 {
+		t.Errorf("second read: got %d, want new reset value 7", got)
+	}
+}
+
+// TestDeltaOnRead_KeepsTotal verifies reads return increments while the
+// running total is preserved.
+func TestDeltaOnRead_KeepsTotal(t *testing.T) {
+	clk := clock.NewPeriodicClock(1 * time.Millisecond)
+	src := source.NewConstSource(clk, 2)
+
+	val := value.New(src).
+		AddTransform(transform.NewAccumulate[int]()).
+		SetInitial(10).
+		EnableDeltaOnRead().
+		SetSynchronous().
+		Start()
+	defer val.Stop()
+
+	clk.Start()
+	defer clk.Stop()
+
+	val.Step()
+	val.Step()
+	if got := val.Value(); got != 4 {
+		t.Errorf("first read: got %d, want 4 since the baseline", got)
+	}
+	if got := val.Peek(); got != 14 {
+		t.Errorf("Peek: got %d, want running total 14", got)
+	}
+
+	val.Step()
+	if got := val.Value(); got != 2 {
+		t.Errorf("second read: got %d, want 2", got)
+	}
+	if got := val.Value(); got != 0 {
+		t.Errorf("read without update: got %d, want 0", got)
+	}
+	if got := val.Stats().CurrentValue; got != 16 {
+		t.Errorf("Stats: got %d, want 16", got)
+	}
+}
+
+// TestExportWindow_ResetOnRead verifies the current value and the recent
+// history are read together, with reset-on-read applied to the current
+// value only.
+func TestExportWindow_ResetOnRead(t *testing.T) {
+	pub := chanPublisher[int]{ch: make(chan int, 8)}
+	val := value.New[int](pub).
+		AddTransform(transform.NewAccumulate[int]()).
+		EnableResetOnRead(0).
+		EnableHistory(3).
+		SetSynchronous().
+		Start()
+	defer val.Stop()
+
+	for i := 1; i <= 4; i++ {
+		pub.ch <- i
+		val.Step()
+	}
+	current, recent := val.ExportWindow(2)
+	if current != 10 || !slices.Equal(recent, []int{6, 10}) {
+		t.Errorf("first export: got %d %v, want 10 [6 10]", current, recent)
+	}
+
+	pub.ch <- 5
+	val.Step()
+	current, recent = val.ExportWindow(5)
+	if current != 5 || !slices.Equal(recent, []int{6, 10, 5}) {
+		t.Errorf("second export: got %d %v, want 5 and the full history [6 10 5]", current, recent)
+	}
+	if got := val.History(); !slices.Equal(got, []int{6, 10, 5}) {
+		t.Errorf("History: got %v, want [6 10 5]", got)
+	}
+}
+
+// intervalClock is a clock whose ticks are counted by calling tick, for
+// exact control over interval boundaries. It never delivers ticks.
+type intervalClock struct{ ticks atomic.Uint64 }
+
+func (c *intervalClock) tick()                      { c.ticks.Add(1) }
+func (c *intervalClock) Subscribe() <-chan struct{} { return nil }
+func (c *intervalClock) Start()                     {}
+func (c *intervalClock) Stop()                      {}
+func (c *intervalClock) Stats() clock.ClockStats {
+	return clock.ClockStats{TickCount: c.ticks.Load()}
+}
+
+// TestResetSharing_PerInterval verifies all readers of one clock interval
+// share a snapshot, and updates during the interval are kept for the next.
+func TestResetSharing_PerInterval(t *testing.T) {
+	interval := &intervalClock{}
+
+	pub := chanPublisher[int]{ch: make(chan int, 8)}
+	val := value.New[int](pub).
+		AddTransform(transform.NewAccumulate[int]()).
+		EnableResetOnRead(0).
+		SetResetSharing(value.ResetPerInterval, interval).
+		SetSynchronous().
+		Start()
+	defer val.Stop()
+
+	pub.ch <- 1
+	pub.ch <- 2
+	val.Step()
+	val.Step()
+	if a, b := val.Value(), val.Value(); a != 3 || b != 3 {
+		t.Errorf("first interval: got reads %d and %d, want 3 for both", a, b)
+	}
+
+	pub.ch <- 4
+	val.Step()
+	if got := val.Value(); got != 3 {
+		t.Errorf("update within interval: got %d, want the shared snapshot 3", got)
+	}
+
+	interval.tick()
+	if a, b := val.Value(), val.Value(); a != 4 || b != 4 {
+		t.Errorf("second interval: got reads %d and %d, want 4 for both", a, b)
+	}
+	if got := val.Peek(); got != 0 {
+		t.Errorf("Peek: got %d, want reset value 0", got)
+	}
+}
+
+// TestStartSnapshotter_InvalidArguments verifies invalid arguments panic
+// in the caller instead of crashing the snapshotter goroutine.
+func TestStartSnapshotter_InvalidArguments(t *testing.T) {
+	for name, tc := range map[string]struct {
+		interval time.Duration
+		sink     func(int)
+	}{
+		"zero interval":     {0, func(int) {}},
+		"negative interval": {-time.Second, func(int) {}},
+		"nil sink":          {time.Second, nil},
+	} {
+		t.Run(name, func(t *testing.T) {
+			val := value.New[int](chanPublisher[int]{ch: make(chan int)})
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			val.StartSnapshotter(tc.interval, tc.sink)
+		})
+	}
+}
+
+// TestStrictPipeline_RunningSumWithResetOnRead verifies a running sum
+// combined with reset-on-read fails a strict Start and is only logged as
+// a warning otherwise.
+func TestStrictPipeline_RunningSumWithResetOnRead(t *testing.T) {
+	build := func(strict bool) *value.Value[int] {
+		return value.New[int](chanPublisher[int]{ch: make(chan int)}).
+			AddTransform(transform.NewBatchSum[int](4)).
+			EnableResetOnRead(0).
+			SetStrictPipeline(strict)
+	}
+
+	if err := build(true).TryStart(); err == nil || !strings.Contains(err.Error(), "BatchSum") {
+		t.Errorf("strict: got error %v, want one naming BatchSum", err)
+	}
+
+	var logged strings.Builder
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	val := build(false)
+	if err := val.TryStart(); err != nil {
+		t.Fatalf("non-strict: got error %v, want a warning only", err)
+	}
+	if err := val.TryStart(); !errors.Is(err, value.ErrAlreadyStarted) {
+		t.Errorf("second TryStart: got %v, want ErrAlreadyStarted", err)
+	}
+	val.Stop()
+	if !strings.Contains(logged.String(), "warning") || !strings.Contains(logged.String(), "reset-on-read") {
+		t.Errorf("non-strict: got log %q, want a reset-on-read warning", logged.String())
+	}
+	if n := strings.Count(logged.String(), "warning"); n != 1 {
+		t.Errorf("non-strict: got %d warnings, want 1 from the starting call only", n)
+	}
+}
+
+// TestAccumulateProduct_Pipeline verifies a product compounds the
+// SetInitial baseline, restarts from a reset value of 1, and fails Start
+// with a reset value of 0.
+func TestAccumulateProduct_Pipeline(t *testing.T) {
+	pub := chanPublisher[float64]{ch: make(chan float64, 8)}
+	val := value.New[float64](pub).
+		AddTransform(transform.NewAccumulateProduct[float64]()).
+		SetInitial(100).
+		EnableResetOnRead(1).
+		SetSynchronous().
+		Start()
+	defer val.Stop()
+
+	pub.ch <- 1.5
+	pub.ch <- 2
+	val.Step()
+	val.Step()
+	if got := val.Value(); got != 300 {
+		t.Errorf("first read: got %v, want 300 compounding the baseline", got)
+	}
+
+	pub.ch <- 4
+	val.Step()
+	if got := val.Value(); got != 4 {
+		t.Errorf("after reset: got %v, want 4", got)
+	}
+
+	err := value.New[float64](chanPublisher[float64]{ch: make(chan float64)}).
+		AddTransform(transform.NewAccumulateProduct[float64]()).
+		EnableResetOnRead(0).
+		TryStart()
+	if err == nil || !strings.Contains(err.Error(), "AccumulateProduct") {
+		t.Errorf("reset to 0: got error %v, want one naming AccumulateProduct", err)
+	}
+}
+
+// TestStart_NilSourceChannel verifies a source returning a nil channel
+// fails Start instead of leaving Stop blocked.
+func TestStart_NilSourceChannel(t *testing.T) {
+	val := value.New[int](chanPublisher[int]{}) // nil ch
+
+	if err := val.TryStart(); !errors.Is(err, value.ErrNilSourceChannel) {
+		t.Fatalf("TryStart: got %v, want ErrNilSourceChannel", err)
+	}
+	select {
+	case <-val.Done():
+	default:
+		t.Error("Done not closed")
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		val.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Stop blocked")
+	}
+}
+
+// TestInputBuffer_QueuesAndDrops verifies source values queue while the
+// pipeline is stalled and overflow is dropped and counted.
+func TestInputBuffer_QueuesAndDrops(t *testing.T) {
+	pub := chanPublisher[int]{ch: make(chan int)}
+	val := value.New[int](pub).
+		SetInputBuffer(3).
+		SetInputOverflow(value.InputDrop)
+	updates := val.Subscribe() // unread, so the update loop stalls
+	val.Start()
+	defer val.Stop()
+
+	pub.ch <- 1
+	waitFor(t, "first update", func() bool { return val.Stats().UpdateCount == 1 })
+
+	for i := 2; i <= 6; i++ {
+		pub.ch <- i // 2-4 fill the queue, 5 and 6 overflow
+	}
+	waitFor(t, "dropped inputs", func() bool { return val.Stats().DroppedInputs == 2 })
+	if got := val.QueueDepth(); got != 3 {
+		t.Errorf("QueueDepth: got %d, want 3", got)
+	}
+
+	for want := 1; want <= 4; want++ {
+		if got := <-updates; got != want {
+			t.Fatalf("update: got %d, want %d", got, want)
+		}
+	}
+}
+
+// TestInputBuffer_StatsDuringStart verifies QueueDepth and Stats may be
+// read while another goroutine starts the value (run with -race).
+func TestInputBuffer_StatsDuringStart(t *testing.T) {
+	val := value.New[int](chanPublisher[int]{ch: make(chan int)}).SetInputBuffer(2)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range 1000 {
+			_ = val.QueueDepth()
+			_ = val.Stats().DroppedInputs
+		}
+	}()
+	val.Start()
+	<-done
+	val.Stop()
+}
+
+// TestSubscribeWithReplay_NoDuplicate verifies a subscriber joining while
+// an update is still being delivered gets it once, as the replayed state.
+func TestSubscribeWithReplay_NoDuplicate(t *testing.T) {
+	pub := chanPublisher[int]{ch: make(chan int)}
+	val := value.New[int](pub).AddTransform(transform.NewAccumulate[int]())
+	slow := val.Subscribe() // holds up delivery of each update
+	val.Start()
+	defer val.Stop()
+
+	pub.ch <- 5
+	for val.Stats().UpdateCount < 1 {
+		runtime.Gosched()
+	}
+
+	// The update is applied but its delivery is blocked on slow
+	replay := val.SubscribeWithReplay()
+	if got := <-replay; got != 5 {
+		t.Fatalf("replayed: got %d, want 5", got)
+	}
+	<-slow
+
+	pub.ch <- 2
+	<-slow
+	if got := <-replay; got != 7 {
+		t.Errorf("next update: got %d, want 7 (5 must not be delivered again)", got)
+	}
+}
+
+// TestAddAlarm_EnterExit verifies callbacks fire on predicate transitions
+// only, and may read the value without deadlocking.
+func TestAddAlarm_EnterExit(t *testing.T) {
+	pub := chanPublisher[int]{ch: make(chan int)}
+	events := make(chan string, 10)
+
+	var val *value.Value[int]
+	val = value.New[int](pub).
+		AddTransform(transform.NewAccumulate[int]()).
+		AddAlarm("high", func(x int) bool { return x >= 3 },
+			func() { events <- fmt.Sprintf("enter at %d", val.Value()) },
+			func() { events <- fmt.Sprintf("exit at %d", val.Value()) }).
+		Start()
+	defer val.Stop()
+
+	for _, in := range []int{1, 1, 1, 1, -3, -1} {
+		pub.ch <- in // totals 1, 2, 3, 4, 1, 0
+	}
+	for _, want := range []string{"enter at 3", "exit at 1"} {
+		select {
+		case got := <-events:
+			if got != want {
+				t.Errorf("got event %q, want %q", got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for %q", want)
+		}
+	}
+	val.StopAndDrain()
+	if len(events) != 0 || val.AlarmActive("high") {
+		t.Errorf("got %d extra events, active=%v; want none and inactive", len(events), val.AlarmActive("high"))
+	}
+}
+
+// inSequence passes inputs through while each is one more than the state,
+// and fails with -1 for good on the first out-of-order input.
+type inSequence struct{}
+
+func (inSequence) Apply(incoming int, state transform.State[int]) int {
+	if prev := state.GetState(); prev < 0 || incoming != prev+1 {
+		return -1
+	}
+	return incoming
+}
+func (inSequence) Name() string { return "InSequence" }
+
+// TestScheduler_PerValueOrder verifies scheduled values process their
+// updates in order, finish when their source closes, and stop cleanly.
+func TestScheduler_PerValueOrder(t *testing.T) {
+	const values, updates = 50, 100
+	sched := value.NewScheduler(4)
+	defer sched.Stop()
+
+	vals := make([]*value.Value[int], values)
+	for i := range vals {
+		pub := chanPublisher[int]{ch: make(chan int)}
+		vals[i] = value.New[int](pub).
+			AddTransform(inSequence{}).
+			SetScheduler(sched).
+			Start()
+		go func() {
+			for n := 1; n <= updates; n++ {
+				pub.ch <- n
+			}
+			close(pub.ch)
+		}()
+	}
+
+	for i, v := range vals {
+		select {
+		case <-v.Done():
+		case <-time.After(5 * time.Second):
+			t.Fatalf("value %d: not finished after its source closed", i)
+		}
+		if got := v.Peek(); got != updates {
+			t.Errorf("value %d: got %d, want %d (-1 means out of order)", i, got, updates)
+		}
+	}
+
+	// A value whose source stays open stops on request
+	open := value.New[int](chanPublisher[int]{ch: make(chan int)}).
+		SetScheduler(sched).
+		Start()
+	open.Stop()
+	<-open.Done()
+}
+
+// TestStop_NoGoroutineLeak verifies stopping a value while its source is
+// still emitting leaves no goroutine behind.
+func TestStop_NoGoroutineLeak(t *testing.T) {
+	clk := clock.NewPeriodicClock(1 * time.Millisecond)
+	src := source.NewConstSource(clk, 1)
+
+	// Keeps the source running for the whole test
+	keep := value.New(src).Start()
+	defer keep.Stop()
+
+	clk.Start()
+	defer clk.Stop()
+
+	if _, ok := keep.WaitValue(time.Second); !ok {
+		t.Fatal("no update received within timeout")
+	}
+	baseline := runtime.NumGoroutine()
+
+	for range 10 {
+		val := value.New(src).Start()
+		if _, ok := val.WaitValue(time.Second); !ok {
+			t.Fatal("no update received within timeout")
+		}
+		val.Stop()
+	}
+
+	// Exiting goroutines may still be finishing after Stop returns
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > baseline && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := runtime.NumGoroutine(); got > baseline {
+		t.Errorf("goroutines after Stop: got %d, want at most %d", got, baseline)
+	}
+	if got := src.Stats().SubscriberCount; got != 1 {
+		t.Errorf("source subscribers: got %d, want 1", got)
+	}
+}
+
+// TestSetMaxDuration_AutoStop verifies the value stops on its own and keeps
+// its last state.
+func TestSetMaxDuration_AutoStop(t *testing.T) {
+	clk := clock.NewPeriodicClock(1 * time.Millisecond)
+	src := source.NewConstSource(clk, 1)
+
+	val := value.New(src).
+		AddTransform(transform.NewAccumulate[int]()).
+		SetMaxDuration(20 * time.Millisecond).
+		Start()
+	defer val.Stop()
+
+	clk.Start()
+	defer clk.Stop()
+
+	select {
+	case <-val.Done():
+	case <-time.After(time.Second):
+		t.Fatal("value did not stop within timeout")
+	}
+
+	last := val.Stats().CurrentValue
+	if last == 0 {
+		t.Fatal("no update received before auto-stop")
+	}
+	time.Sleep(5 * time.Millisecond)
+	if got := val.Value(); got != last {
+		t.Errorf("after auto-stop: got %d, want last state %d", got, last)
+	}
+}
+
+// TestClone_IndependentState verifies a clone shares the source and
+// configuration but not transform state.
+func TestClone_IndependentState(t *testing.T) {
+	clk := clock.NewPeriodicClock(1 * time.Millisecond)
+	src := source.NewConstSource(clk, 1)
+
+	a := value.New(src).
+		AddTransform(transform.NewBatchSum[int](3)).
+		SetSynchronous()
+	b := a.Clone()
+	a.Start()
+	defer a.Stop()
+	b.Start()
+	defer b.Stop()
+
+	clk.Start()
+	defer clk.Stop()
+
+	// The source sends each tick to both values, so steps interleave
+	a.Step()
+	b.Step()
+	a.Step()
+
+	// A shared batch would have counted all three steps
+	if got := a.Peek(); got != 2 {
+		t.Errorf("original: got %d, want 2", got)
+	}
+	if got := b.Peek(); got != 1 {
+		t.Errorf("clone: got %d, want 1", got)
+	}
+}
+
+// panicHook panics after every update.
+type panicHook[T any] struct{}
+
+func (panicHook[T]) OnInput(T, T)                {}
+func (panicHook[T]) OnTransform(string, T, T, T) {}
+func (panicHook[T]) AfterUpdate(T)               { panic("hook bug") }
+
+// TestHookPanicMode_Propagate verifies hook panics reach the caller in
+// propagate mode and are isolated otherwise.
+func TestHookPanicMode_Propagate(t *testing.T) {
+	for _, mode := range []value.HookPanicMode{value.HookPanicIgnore, value.HookPanicPropagate} {
+		t.Run(mode.String(), func(t *testing.T) {
+			clk := clock.NewPeriodicClock(1 * time.Millisecond)
+			src := source.NewConstSource(clk, 1)
+
+			val := value.New(src).
+				SetSynchronous().
+				SetHookPanicMode(mode).
+				SetUpdateHook(panicHook[int]{}).
+				Start()
+			defer val.Stop()
+
+			clk.Start()
+			defer clk.Stop()
+
+			panicked := func() (p bool) {
+				defer func() { p = recover() != nil }()
+				val.Step()
+				return false
+			}()
+			if want := mode == value.HookPanicPropagate; panicked != want {
+				t.Errorf("panicked: got %v, want %v", panicked, want)
+			}
+		})
+	}
+}
+
+// TestSubscribeWithCancel_ClosesChannel verifies cancelling closes the
+// subscription without blocking the value.
+func TestSubscribeWithCancel_ClosesChannel(t *testing.T) {
+	clk := clock.NewPeriodicClock(1 * time.Millisecond)
+	src := source.NewConstSource(clk, 1)
+
+	val := value.New(src).
+		AddTransform(transform.NewAccumulate[int]()).
+		Start()
+	defer val.Stop()
+
+	updates, cancel := val.SubscribeWithCancel()
+
+	clk.Start()
+	defer clk.Stop()
+
+	<-updates
+	cancel()
+	cancel() // no effect
+	if _, ok := <-updates; ok {
+		t.Error("channel still open after cancel")
+	}
+
+	before := val.Stats().UpdateCount
+	time.Sleep(10 * time.Millisecond)
+	if after := val.Stats().UpdateCount; after <= before {
+		t.Errorf("value stalled after cancel: %d updates before, %d after", before, after)
+	}
+}
+
+// TestDemux_RoutesAndRemoves verifies keys get independent values on first
+// emission, a removed key starts fresh, and finished values stay readable
+// after the source closes until Stop.
+func TestDemux_RoutesAndRemoves(t *testing.T) {
+	pub := chanPublisher[source.Keyed[string, int]]{ch: make(chan source.Keyed[string, int])}
+	emit := func(key string, v int) { pub.ch <- source.Keyed[string, int]{Key: key, Value: v} }
+
+	d := value.NewDemux(pub, func(_ string, v *value.Value[int]) {
+		v.AddTransform(transform.NewAccumulate[int]())
+	}).Start()
+	defer d.Stop()
+
+	emit("a", 1)
+	emit("b", 2)
+	emit("a", 3) // received only after b was routed
+
+	b, ok := d.Get("b")
+	if !ok {
+		t.Fatal("Get(b): no value")
+	}
+	if !d.Remove("b") {
+		t.Fatal("Remove(b): reported no value")
+	}
+	<-b.Done()
+	if got := b.Peek(); got != 2 {
+		t.Errorf("removed b: got %d, want 2", got)
+	}
+
+	emit("b", 5)
+	emit("a", 4)
+	a, _ := d.Get("a")
+	newB, _ := d.Get("b")
+	close(pub.ch) // values finish once their pending emissions are applied
+
+	<-a.Done()
+	<-newB.Done()
+	if got := a.Peek(); got != 8 {
+		t.Errorf("a: got %d, want 8", got)
+	}
+	if newB == b || newB.Peek() != 5 {
+		t.Errorf("b after removal: got %d, want fresh value with 5", newB.Peek())
+	}
+	if keys := d.Keys(); len(keys) != 2 {
+		t.Errorf("Keys after source closed: got %v, want a and b kept", keys)
+	}
+	if got, ok := d.Get("a"); !ok || got != a || got.Peek() != 8 {
+		t.Errorf("Get(a) after source closed: got %v, want the finished value", ok)
+	}
+
+	d.Stop()
+	if keys := d.Keys(); len(keys) != 0 {
+		t.Errorf("Keys after Stop: got %v, want none", keys)
+	}
+}
+
+// TestGraphDOT_SharedUpstream verifies shared nodes appear once and edges
+// follow upstream references.
+func TestGraphDOT_SharedUpstream(t *testing.T) {
+	clk := clock.NewPeriodicClock(time.Millisecond)
+	src := source.NewConstSource(clk, 1)
+	a := value.New(src).AddTransform(transform.NewAccumulate[int]())
+	b := value.New(src).AddTransform(identity[int]{})
+
+	dot := value.GraphDOT(a, b)
+
+	if n := strings.Count(dot, "label=\"source.ConstSource[int]\""); n != 1 {
+		t.Errorf("shared source drawn %d times, want 1:\n%s", n, dot)
+	}
+	for _, want := range []string{
+		"n0 [label=\"value.Value[int]\\nAccumulate\", shape=box];",
+		"n1 -> n0;", // source -> a
+		"n2 -> n1;", // clock -> source
+		"n1 -> n3;", // source -> b
+		"Identity",
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("missing %q in:\n%s", want, dot)
+		}
+	}
+}